@@ -0,0 +1,396 @@
+package ebird
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RegionHop is a region and its distance in hops from the region a search
+// started at.
+type RegionHop struct {
+	Code string
+	Hops int
+}
+
+// AdjacencyGraph is a graph of regions built lazily from AdjacentRegions.
+// Each region's neighbours are fetched once and cached; it is safe for
+// concurrent use.
+type AdjacencyGraph struct {
+	client      *Client
+	concurrency int
+
+	mu      sync.Mutex
+	entries map[string]*adjacencyEntry
+}
+
+type adjacencyEntry struct {
+	done      chan struct{}
+	neighbors []string
+	err       error
+}
+
+type AdjacencyGraphOption func(*AdjacencyGraph)
+
+// WithGraphConcurrency sets how many AdjacentRegions requests run at once
+// when a search expands several regions. The default is 4.
+func WithGraphConcurrency(n int) AdjacencyGraphOption {
+	return func(g *AdjacencyGraph) {
+		if n > 0 {
+			g.concurrency = n
+		}
+	}
+}
+
+func NewAdjacencyGraph(client *Client, opts ...AdjacencyGraphOption) *AdjacencyGraph {
+	g := &AdjacencyGraph{
+		client:      client,
+		concurrency: defaultConcurrency,
+		entries:     make(map[string]*adjacencyEntry),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Neighbors returns the codes of the regions adjacent to code. Concurrent
+// calls for the same region share one request, which is not cancelled when
+// one of the callers gives up; each caller stops waiting when its own ctx is
+// done. Failed requests are not cached.
+func (g *AdjacencyGraph) Neighbors(ctx context.Context, code string) ([]string, error) {
+	regionCode, err := ParseRegionCode(code)
+	if err != nil {
+		return nil, err
+	}
+	code = regionCode.String()
+
+	g.mu.Lock()
+	entry, ok := g.entries[code]
+	if !ok {
+		entry = &adjacencyEntry{done: make(chan struct{})}
+		g.entries[code] = entry
+		go g.fetch(detachedContext{ctx}, code, entry)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return append([]string(nil), entry.neighbors...), nil
+}
+
+func (g *AdjacencyGraph) fetch(ctx context.Context, code string, entry *adjacencyEntry) {
+	defer close(entry.done)
+
+	regions, err := g.client.AdjacentRegions(ctx, code)
+	if err != nil {
+		entry.err = err
+		g.mu.Lock()
+		delete(g.entries, code)
+		g.mu.Unlock()
+		return
+	}
+	entry.neighbors = make([]string, 0, len(regions))
+	for _, r := range regions {
+		entry.neighbors = append(entry.neighbors, r.Code)
+	}
+}
+
+// detachedContext keeps the values of its parent but not its deadline or
+// cancellation, so that a request shared by several callers is not cut short
+// by the one that started it.
+type detachedContext struct{ context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// canonicalRegions returns codes in canonical form.
+func canonicalRegions(codes ...string) ([]string, error) {
+	result := make([]string, len(codes))
+	for i, code := range codes {
+		regionCode, err := ParseRegionCode(code)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = regionCode.String()
+	}
+	return result, nil
+}
+
+// expand fetches the neighbours of every code, a few at a time.
+func (g *AdjacencyGraph) expand(ctx context.Context, codes []string) (map[string][]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	result := make(map[string][]string, len(codes))
+	sem := make(chan struct{}, g.concurrency)
+	var wg sync.WaitGroup
+
+	for _, code := range codes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(code string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			neighbors, err := g.Neighbors(ctx, code)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			result[code] = neighbors
+		}(code)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Within returns code and every region at most hops steps away from it,
+// ordered by distance and then by code.
+func (g *AdjacencyGraph) Within(ctx context.Context, code string, hops int) ([]RegionHop, error) {
+	if code == "" {
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	if hops < 0 {
+		return nil, invalidArgument("hops cannot be negative")
+	}
+	codes, err := canonicalRegions(code)
+	if err != nil {
+		return nil, err
+	}
+	code = codes[0]
+
+	dist := map[string]int{code: 0}
+	frontier := []string{code}
+	for h := 1; h <= hops && len(frontier) > 0; h++ {
+		neighbors, err := g.expand(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand adjacency graph: %w", err)
+		}
+		var next []string
+		for _, from := range frontier {
+			for _, n := range neighbors[from] {
+				if _, seen := dist[n]; !seen {
+					dist[n] = h
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	result := make([]RegionHop, 0, len(dist))
+	for c, h := range dist {
+		result = append(result, RegionHop{Code: c, Hops: h})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Hops != result[j].Hops {
+			return result[i].Hops < result[j].Hops
+		}
+		return result[i].Code < result[j].Code
+	})
+	return result, nil
+}
+
+// ShortestPath returns the regions on a shortest path from one region to
+// another, both included. The search gives up after maxHops steps and
+// returns an error matching ErrNotFound.
+func (g *AdjacencyGraph) ShortestPath(ctx context.Context, from, to string, maxHops int) ([]string, error) {
+	if from == "" || to == "" {
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	codes, err := canonicalRegions(from, to)
+	if err != nil {
+		return nil, err
+	}
+	from, to = codes[0], codes[1]
+	if from == to {
+		return []string{from}, nil
+	}
+
+	prev := map[string]string{from: ""}
+	frontier := []string{from}
+	for h := 1; h <= maxHops && len(frontier) > 0; h++ {
+		neighbors, err := g.expand(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand adjacency graph: %w", err)
+		}
+		var next []string
+		for _, f := range frontier {
+			for _, n := range neighbors[f] {
+				if _, seen := prev[n]; seen {
+					continue
+				}
+				prev[n] = f
+				if n == to {
+					var path []string
+					for c := to; c != ""; c = prev[c] {
+						path = append([]string{c}, path...)
+					}
+					return path, nil
+				}
+				next = append(next, n)
+			}
+		}
+		frontier = next
+	}
+	return nil, fmt.Errorf("no path from %s to %s within %d hops: %w", from, to, maxHops, ErrNotFound)
+}
+
+// ConnectedComponents splits codes into groups of regions that are
+// connected through adjacencies between regions in codes. Groups and the
+// codes in them are sorted.
+func (g *AdjacencyGraph) ConnectedComponents(ctx context.Context, codes []string) ([][]string, error) {
+	codes, err := canonicalRegions(codes...)
+	if err != nil {
+		return nil, err
+	}
+	neighbors, err := g.expand(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand adjacency graph: %w", err)
+	}
+
+	inSet := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		inSet[c] = true
+	}
+
+	// Adjacency is treated as undirected in case the API lists a pair in
+	// only one direction.
+	edges := make(map[string][]string)
+	for c, ns := range neighbors {
+		for _, n := range ns {
+			if inSet[n] {
+				edges[c] = append(edges[c], n)
+				edges[n] = append(edges[n], c)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var components [][]string
+	for _, c := range codes {
+		if seen[c] {
+			continue
+		}
+		var component []string
+		stack := []string{c}
+		seen[c] = true
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, cur)
+			for _, n := range edges[cur] {
+				if !seen[n] {
+					seen[n] = true
+					stack = append(stack, n)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components, nil
+}
+
+// NotableWithin runs RecentNotableObservationsInRegion for code and every
+// region within hops steps of it and merges the results. An observation
+// reported in several regions is returned once. Results are sorted newest
+// first. Request options are passed to every call; Concurrency limits how
+// many run at once.
+func (g *AdjacencyGraph) NotableWithin(ctx context.Context, code string, hops int, opts ...RequestOption) ([]Observation, error) {
+	regions, err := g.Within(ctx, code, hops)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := processOptions(opts...).concurrency
+	if concurrency <= 0 {
+		concurrency = g.concurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	seen := make(map[string]bool)
+	var observations []Observation
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for _, r := range regions {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			obs, err := g.client.RecentNotableObservationsInRegion(ctx, region, opts...)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", region, err)
+					cancel()
+				}
+				return
+			}
+			for _, o := range obs {
+				key := o.SubId + "/" + o.SpeciesCode
+				if !seen[key] {
+					seen[key] = true
+					observations = append(observations, o)
+				}
+			}
+		}(r.Code)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(observations, func(i, j int) bool {
+		return observations[i].ObsDt > observations[j].ObsDt
+	})
+	return observations, nil
+}
@@ -0,0 +1,195 @@
+package ebird
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// US-NY-001 - US-NY-002 - US-NY-003 - US-NY-004, plus US-NY-002 - US-NY-005.
+var testAdjacency = map[string][]string{
+	"US-NY-001": {"US-NY-002"},
+	"US-NY-002": {"US-NY-001", "US-NY-003", "US-NY-005"},
+	"US-NY-003": {"US-NY-002", "US-NY-004"},
+	"US-NY-004": {"US-NY-003"},
+	"US-NY-005": {"US-NY-002"},
+	"US-NY-009": {},
+}
+
+func newAdjacencyServer(t *testing.T) (*httptest.Server, map[string]int, *sync.Mutex) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/ref/adjacent/"):
+			code := strings.TrimPrefix(r.URL.Path, "/ref/adjacent/")
+			mu.Lock()
+			calls[code]++
+			mu.Unlock()
+			neighbors, ok := testAdjacency[code]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			parts := make([]string, 0, len(neighbors))
+			for _, n := range neighbors {
+				parts = append(parts, fmt.Sprintf(`{"code":%q,"name":%q}`, n, n))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(parts, ","))
+		case strings.HasPrefix(r.URL.Path, "/data/obs/"):
+			code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/data/obs/"), "/recent/notable")
+			switch code {
+			case "US-NY-001":
+				w.Write([]byte(`[{"speciesCode":"snoowl1","subId":"S1","obsDt":"2024-01-02 08:00"}]`))
+			case "US-NY-002":
+				w.Write([]byte(`[{"speciesCode":"snoowl1","subId":"S1","obsDt":"2024-01-02 08:00"},{"speciesCode":"gyrfal","subId":"S2","obsDt":"2024-01-03 09:00"}]`))
+			default:
+				w.Write([]byte(`[]`))
+			}
+		}
+	}))
+	return server, calls, &mu
+}
+
+func TestAdjacencyGraphWithin(t *testing.T) {
+	server, calls, mu := newAdjacencyServer(t)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	g := NewAdjacencyGraph(client, WithGraphConcurrency(2))
+	ctx := context.Background()
+
+	got, err := g.Within(ctx, "US-NY-001", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []RegionHop{
+		{Code: "US-NY-001", Hops: 0},
+		{Code: "US-NY-002", Hops: 1},
+		{Code: "US-NY-003", Hops: 2},
+		{Code: "US-NY-005", Hops: 2},
+	}, got)
+
+	got, err = g.Within(ctx, "US-NY-001", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []RegionHop{{Code: "US-NY-001", Hops: 0}}, got)
+
+	_, err = g.Within(ctx, "US-NY-001", 3)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, calls["US-NY-001"], "neighbours should be cached")
+	assert.Equal(t, 1, calls["US-NY-002"])
+	assert.Equal(t, 0, calls["US-NY-004"])
+}
+
+func TestAdjacencyGraphNeighborsSharedRequest(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		started <- struct{}{}
+		<-release
+		w.Write([]byte(`[{"code":"US-NY-002","name":"US-NY-002"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	g := NewAdjacencyGraph(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := g.Neighbors(ctx, "us-ny-001")
+		first <- err
+	}()
+	<-started
+
+	second := make(chan []string, 1)
+	go func() {
+		neighbors, err := g.Neighbors(context.Background(), "US-NY-001")
+		assert.NoError(t, err)
+		second <- neighbors
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+	close(release)
+	assert.Equal(t, []string{"US-NY-002"}, <-second)
+
+	_, err = g.Neighbors(context.Background(), "us-ny-001")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestAdjacencyGraphShortestPath(t *testing.T) {
+	server, _, _ := newAdjacencyServer(t)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	g := NewAdjacencyGraph(client)
+	ctx := context.Background()
+
+	path, err := g.ShortestPath(ctx, "US-NY-005", "US-NY-004", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"US-NY-005", "US-NY-002", "US-NY-003", "US-NY-004"}, path)
+
+	path, err = g.ShortestPath(ctx, "US-NY-004", "US-NY-004", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"US-NY-004"}, path)
+
+	_, err = g.ShortestPath(ctx, "US-NY-005", "US-NY-004", 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = g.ShortestPath(ctx, "US-NY-001", "US-NY-009", 10)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAdjacencyGraphConnectedComponents(t *testing.T) {
+	server, _, _ := newAdjacencyServer(t)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	g := NewAdjacencyGraph(client)
+
+	components, err := g.ConnectedComponents(context.Background(), []string{"US-NY-004", "US-NY-001", "US-NY-002", "US-NY-009", "US-NY-005"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"US-NY-001", "US-NY-002", "US-NY-005"},
+		{"US-NY-004"},
+		{"US-NY-009"},
+	}, components)
+
+	_, err = g.ConnectedComponents(context.Background(), []string{"US-NY-001", "US-NY-404"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAdjacencyGraphNotableWithin(t *testing.T) {
+	server, _, _ := newAdjacencyServer(t)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	g := NewAdjacencyGraph(client)
+
+	obs, err := g.NotableWithin(context.Background(), "US-NY-003", 2, Back(7), Concurrency(2))
+	require.NoError(t, err)
+	require.Len(t, obs, 2)
+	assert.Equal(t, "gyrfal", obs[0].SpeciesCode)
+	assert.Equal(t, "snoowl1", obs[1].SpeciesCode)
+
+	_, err = g.NotableWithin(context.Background(), "", 1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,106 @@
+package ebird
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate go run ./internal/genapi
+
+// API is the full set of eBird API calls. *Client implements it; tests can
+// use the mocks in ebirdmock and wrappers can be built with Decorate.
+//
+// Code that only needs part of the API should accept one of the smaller
+// interfaces instead.
+type API interface {
+	ObservationsAPI
+	ProductAPI
+	HotspotAPI
+	TaxonomyAPI
+	RegionAPI
+}
+
+// ObservationsAPI covers the data/obs endpoints and the recent checklist
+// feed.
+type ObservationsAPI interface {
+	RecentObservationsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]Observation, error)
+	RecentNotableObservationsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]Observation, error)
+	RecentObservationsOfSpeciesInRegion(ctx context.Context, regionCode, speciesCode string, opts ...RequestOption) ([]Observation, error)
+	RecentNearbyObservations(ctx context.Context, opts ...RequestOption) ([]Observation, error)
+	RecentNearbyObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...RequestOption) ([]Observation, error)
+	NearestObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...RequestOption) ([]Observation, error)
+	RecentNearbyNotableObservations(ctx context.Context, opts ...RequestOption) ([]Observation, error)
+	RecentChecklistsFeed(ctx context.Context, regionCode string, opts ...RequestOption) ([]RecentChecklistFeed, error)
+	HistoricObservationsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]Observation, error)
+}
+
+// ProductAPI covers the product endpoints.
+type ProductAPI interface {
+	Top100(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]Top100, error)
+	ChecklistFeedOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]ChecklistFeedOnDate, error)
+	RegionalStatisticsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) (*RegionalStatisticsOnDate, error)
+	SpeciesListForRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]string, error)
+	ViewChecklist(ctx context.Context, subId string, opts ...RequestOption) (*ViewChecklist, error)
+}
+
+// HotspotAPI covers the ref/hotspot endpoints.
+type HotspotAPI interface {
+	HotspotsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]HotspotInRegion, error)
+	NearbyHotspots(ctx context.Context, opts ...RequestOption) ([]NearbyHotspot, error)
+	HotspotsInRegionCSV(ctx context.Context, regionCode string, opts ...RequestOption) (io.ReadCloser, error)
+	NearbyHotspotsCSV(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error)
+	HotspotInfo(ctx context.Context, locId string, opts ...RequestOption) (*HotspotInfo, error)
+}
+
+// TaxonomyAPI covers the ref/taxonomy and ref/taxa-locales endpoints.
+type TaxonomyAPI interface {
+	EbirdTaxonomy(ctx context.Context, opts ...RequestOption) ([]EbirdTaxon, error)
+	EbirdTaxonomyCSV(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error)
+	TaxonomicForms(ctx context.Context, speciesCode string, opts ...RequestOption) ([]string, error)
+	TaxonomicGroups(ctx context.Context, speciesGrouping string, opts ...RequestOption) ([]TaxonomicGroup, error)
+	TaxonomyVersions(ctx context.Context, opts ...RequestOption) ([]TaxonomyVersion, error)
+	TaxaLocaleCodes(ctx context.Context, opts ...RequestOption) ([]TaxaLocaleCode, error)
+}
+
+// RegionAPI covers the ref/region and ref/adjacent endpoints.
+type RegionAPI interface {
+	RegionInfo(ctx context.Context, regionCode string, opts ...RequestOption) (*RegionInfo, error)
+	SubRegionList(ctx context.Context, regionType, parentRegionCode string, opts ...RequestOption) ([]SubRegion, error)
+	AdjacentRegions(ctx context.Context, regionCode string, opts ...AdjacentRegionsOption) ([]AdjacentRegion, error)
+}
+
+var _ API = (*Client)(nil)
+
+// Decorator wraps an API, for example to add logging or metrics.
+type Decorator func(API) API
+
+// Decorate wraps api with decorators. The first decorator is the outermost,
+// so it sees each call first.
+func Decorate(api API, decorators ...Decorator) API {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		api = decorators[i](api)
+	}
+	return api
+}
+
+// Interceptor is called for every call made through an API returned by
+// Intercept. method is the API method name, such as
+// "RecentObservationsInRegion". The interceptor must call invoke to make the
+// call and return its error, or return an error without calling it.
+type Interceptor func(ctx context.Context, method string, invoke func(ctx context.Context) error) error
+
+// Intercept returns a Decorator that runs fn around every call. It is the
+// building block for cross-cutting wrappers:
+//
+//	logged := ebird.Decorate(client, ebird.Intercept(func(ctx context.Context, method string, invoke func(context.Context) error) error {
+//		start := time.Now()
+//		err := invoke(ctx)
+//		log.Printf("%s took %v: %v", method, time.Since(start), err)
+//		return err
+//	}))
+func Intercept(fn Interceptor) Decorator {
+	return func(next API) API {
+		return &interceptedAPI{next: next, fn: fn}
+	}
+}
@@ -0,0 +1,46 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntercept(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"speciesCode":"amecro"}]`))
+	}))
+	defer server.Close()
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	var order []string
+	trace := func(name string) Decorator {
+		return Intercept(func(ctx context.Context, method string, invoke func(context.Context) error) error {
+			order = append(order, name+" "+method)
+			return invoke(ctx)
+		})
+	}
+
+	api := Decorate(client, trace("outer"), trace("inner"))
+	obs, err := api.RecentObservationsInRegion(context.Background(), "US-NY")
+	require.NoError(t, err)
+	assert.Equal(t, "amecro", obs[0].SpeciesCode)
+	assert.Equal(t, []string{"outer RecentObservationsInRegion", "inner RecentObservationsInRegion"}, order)
+}
+
+func TestInterceptShortCircuits(t *testing.T) {
+	errBlocked := errors.New("blocked")
+	api := Decorate(&Client{}, Intercept(func(ctx context.Context, method string, invoke func(context.Context) error) error {
+		return errBlocked
+	}))
+
+	info, err := api.HotspotInfo(context.Background(), "L123")
+	assert.ErrorIs(t, err, errBlocked)
+	assert.Nil(t, info)
+}
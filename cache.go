@@ -0,0 +1,257 @@
+package ebird
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw response bodies for the reference endpoints.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+// CacheFamily groups endpoints that share a cache TTL.
+type CacheFamily string
+
+const (
+	CacheFamilyTaxonomy CacheFamily = "taxonomy"
+	CacheFamilyRegion   CacheFamily = "region"
+	CacheFamilyHotspot  CacheFamily = "hotspot"
+)
+
+var defaultCacheTTLs = map[CacheFamily]time.Duration{
+	CacheFamilyTaxonomy: 24 * time.Hour,
+	CacheFamilyRegion:   24 * time.Hour,
+	CacheFamilyHotspot:  time.Hour,
+}
+
+var cacheFamilyPrefixes = []struct {
+	prefix string
+	family CacheFamily
+}{
+	{"ref/taxonomy/", CacheFamilyTaxonomy},
+	{"ref/taxa-locales/", CacheFamilyTaxonomy},
+	{"ref/taxon/", CacheFamilyTaxonomy},
+	{"ref/sppgroup/", CacheFamilyTaxonomy},
+	{"ref/region/", CacheFamilyRegion},
+	{"ref/adjacent/", CacheFamilyRegion},
+	{"ref/hotspot/", CacheFamilyHotspot},
+}
+
+type cacheMode int
+
+const (
+	cacheDefault cacheMode = iota
+	cacheBypass
+	cacheRefresh
+)
+
+func WithCache(cache Cache) ClientOption {
+	return func(client *Client) {
+		client.cache = cache
+	}
+}
+
+// WithCacheTTL overrides the TTL for an endpoint family. A TTL of zero
+// disables caching for that family.
+func WithCacheTTL(family CacheFamily, ttl time.Duration) ClientOption {
+	return func(client *Client) {
+		if client.cacheTTLs == nil {
+			client.cacheTTLs = make(map[CacheFamily]time.Duration)
+		}
+		client.cacheTTLs[family] = ttl
+	}
+}
+
+func (c *Client) cacheTTL(endpoint string) time.Duration {
+	for _, p := range cacheFamilyPrefixes {
+		if strings.HasPrefix(endpoint, p.prefix) {
+			if ttl, ok := c.cacheTTLs[p.family]; ok {
+				return ttl
+			}
+			return defaultCacheTTLs[p.family]
+		}
+	}
+	return 0
+}
+
+func (c *Client) cacheKey(req *Request) string {
+	lang := req.Header.Get("Accept-Language")
+	if lang == "" {
+		lang = c.acceptLanguage
+	}
+	return req.URL.String() + "\x00" + lang
+}
+
+// MemoryCache is an in-memory LRU cache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an LRU cache holding at most maxEntries responses.
+// A maxEntries of zero means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*memoryCacheEntry)
+	if !entry.expires.IsZero() && m.now().After(entry.expires) {
+		m.removeElement(el)
+		return nil, false
+	}
+
+	m.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = m.now().Add(ttl)
+	}
+
+	if el, ok := m.items[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expires = expires
+		m.ll.MoveToFront(el)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		m.removeElement(m.ll.Back())
+	}
+}
+
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.removeElement(el)
+	}
+}
+
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *MemoryCache) removeElement(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*memoryCacheEntry).key)
+}
+
+// DiskCache stores each response in its own file under a directory. The
+// first line of each file holds the expiry time in Unix nanoseconds.
+type DiskCache struct {
+	dir string
+	now func() time.Time
+}
+
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir, now: time.Now}, nil
+}
+
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 {
+		return nil, false
+	}
+
+	expires, err := strconv.ParseInt(string(data[:i]), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	if expires > 0 && d.now().UnixNano() > expires {
+		os.Remove(d.path(key))
+		return nil, false
+	}
+
+	return data[i+1:], true
+}
+
+func (d *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	var expires int64
+	if ttl > 0 {
+		expires = d.now().Add(ttl).UnixNano()
+	}
+
+	f, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := fmt.Fprintf(f, "%d\n", expires); err != nil {
+		f.Close()
+		return
+	}
+	if _, err := f.Write(value); err != nil {
+		f.Close()
+		return
+	}
+	if err := f.Close(); err != nil {
+		return
+	}
+
+	os.Rename(f.Name(), d.path(key))
+}
+
+func (d *DiskCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
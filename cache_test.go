@@ -0,0 +1,170 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+
+	got, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("1"), got)
+
+	cache.Set("c", []byte("3"), time.Minute)
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+	assert.Equal(t, 2, cache.Len())
+
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok, "expired entry should not be returned")
+
+	cache.Set("d", []byte("4"), 0)
+	cache.Delete("c")
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+	_, ok = cache.Get("d")
+	assert.True(t, ok)
+}
+
+func TestDiskCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache, err := NewDiskCache(t.TempDir())
+	require.NoError(t, err)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("https://api.ebird.org/v2/ref/taxonomy/ebird?fmt=json", []byte("[1,2]\n"), time.Hour)
+	got, ok := cache.Get("https://api.ebird.org/v2/ref/taxonomy/ebird?fmt=json")
+	require.True(t, ok)
+	assert.Equal(t, []byte("[1,2]\n"), got)
+
+	_, ok = cache.Get("missing")
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	_, ok = cache.Get("https://api.ebird.org/v2/ref/taxonomy/ebird?fmt=json")
+	assert.False(t, ok)
+
+	cache.Set("forever", []byte("x"), 0)
+	cache.Delete("forever")
+	_, ok = cache.Get("forever")
+	assert.False(t, ok)
+}
+
+func TestClientCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`[{"authorityVer":2023,"latest":true}]`))
+	}))
+	defer server.Close()
+
+	newClient := func(opts ...ClientOption) *Client {
+		atomic.StoreInt32(&requests, 0)
+		opts = append([]ClientOption{WithBaseURL(server.URL + "/")}, opts...)
+		client, err := NewClient("test-api-key", opts...)
+		require.NoError(t, err)
+		return client
+	}
+	ctx := context.Background()
+
+	t.Run("Reference endpoints are cached", func(t *testing.T) {
+		client := newClient(WithCache(NewMemoryCache(10)))
+		for i := 0; i < 3; i++ {
+			versions, err := client.TaxonomyVersions(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []TaxonomyVersion{{AuthorityVer: 2023, Latest: true}}, versions)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Observations are not cached", func(t *testing.T) {
+		client := newClient(WithCache(NewMemoryCache(10)))
+		for i := 0; i < 2; i++ {
+			_, err := client.RecentObservationsInRegion(ctx, "US-NY")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Query string is part of the key", func(t *testing.T) {
+		client := newClient(WithCache(NewMemoryCache(10)))
+		_, err := client.TaxonomyVersions(ctx)
+		require.NoError(t, err)
+		_, err = client.TaxonomyVersions(ctx, Locale("fr"))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Accept-Language is part of the key", func(t *testing.T) {
+		cache := NewMemoryCache(10)
+		en := newClient(WithCache(cache), WithAcceptLanguage("en"))
+		_, err := en.TaxonomyVersions(ctx)
+		require.NoError(t, err)
+
+		es, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithCache(cache), WithAcceptLanguage("es"))
+		require.NoError(t, err)
+		_, err = es.TaxonomyVersions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("NoCache bypasses the cache", func(t *testing.T) {
+		cache := NewMemoryCache(10)
+		client := newClient(WithCache(cache))
+		_, err := client.TaxonomyVersions(ctx, NoCache())
+		require.NoError(t, err)
+		assert.Equal(t, 0, cache.Len())
+		_, err = client.TaxonomyVersions(ctx)
+		require.NoError(t, err)
+		_, err = client.TaxonomyVersions(ctx, NoCache())
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("RefreshCache refetches and stores", func(t *testing.T) {
+		client := newClient(WithCache(NewMemoryCache(10)))
+		_, err := client.TaxonomyVersions(ctx)
+		require.NoError(t, err)
+		_, err = client.TaxonomyVersions(ctx, RefreshCache())
+		require.NoError(t, err)
+		_, err = client.TaxonomyVersions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Zero TTL disables a family", func(t *testing.T) {
+		client := newClient(WithCache(NewMemoryCache(10)), WithCacheTTL(CacheFamilyTaxonomy, 0))
+		for i := 0; i < 2; i++ {
+			_, err := client.TaxonomyVersions(ctx)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+}
+
+func TestClientCacheTTL(t *testing.T) {
+	client, err := NewClient("test-api-key", WithCacheTTL(CacheFamilyHotspot, 5*time.Minute))
+	require.NoError(t, err)
+
+	assert.Equal(t, 24*time.Hour, client.cacheTTL(APIEndpoints.EbirdTaxonomy))
+	assert.Equal(t, 24*time.Hour, client.cacheTTL("ref/region/info/US-NY"))
+	assert.Equal(t, 24*time.Hour, client.cacheTTL("ref/region/list/subnational1/US"))
+	assert.Equal(t, 5*time.Minute, client.cacheTTL("ref/hotspot/US-NY"))
+	assert.Equal(t, time.Duration(0), client.cacheTTL("data/obs/US-NY/recent"))
+	assert.Equal(t, time.Duration(0), client.cacheTTL("product/checklist/view/S1"))
+}
@@ -0,0 +1,83 @@
+// Command ebird-server runs a caching reverse proxy for the eBird API.
+//
+// It reads the API key from EBIRD_API_KEY and serves the API paths under
+// /v2/, so consumers can use:
+//
+//	client, err := ebird.NewClient("unused", ebird.WithBaseURL("http://localhost:8080/v2/"))
+//
+// Health and request counters are served at /healthz and /stats.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+	"github.com/siansiansu/go-ebird/server"
+)
+
+func main() {
+	var (
+		addr          = flag.String("addr", ":8080", "address to listen on")
+		cacheSize     = flag.Int("cache-size", 10000, "maximum number of cached responses")
+		cacheTTL      = flag.Duration("cache-ttl", 5*time.Minute, "how long to cache each response; 0 disables the response cache")
+		rate          = flag.Float64("rate", 5, "upstream requests per second; 0 disables the limit")
+		burst         = flag.Int("burst", 10, "upstream request burst")
+		consumerRate  = flag.Float64("consumer-rate", 0, "requests per second per consumer address; 0 disables the limit")
+		consumerBurst = flag.Int("consumer-burst", 20, "request burst per consumer address")
+		retries       = flag.Int("retries", 3, "maximum upstream attempts per request")
+		timeout       = flag.Duration("timeout", 30*time.Second, "upstream timeout per request, including retries")
+	)
+	flag.Parse()
+
+	cache := ebird.NewMemoryCache(*cacheSize)
+	client, err := ebird.NewClient(os.Getenv("EBIRD_API_KEY"),
+		ebird.WithCache(cache),
+		ebird.WithRateLimit(*rate, *burst),
+		ebird.WithRetryPolicy(ebird.RetryPolicy{
+			MaxAttempts: *retries,
+			BaseBackoff: 500 * time.Millisecond,
+			MaxBackoff:  10 * time.Second,
+			Jitter:      0.2,
+		}),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []server.Option{server.WithUpstreamTimeout(*timeout)}
+	if *cacheTTL > 0 {
+		opts = append(opts, server.WithCache(cache, *cacheTTL))
+	}
+	if *consumerRate > 0 {
+		opts = append(opts, server.WithConsumerRateLimit(*consumerRate, *consumerBurst))
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(client, opts...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("ebird-server listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
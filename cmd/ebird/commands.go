@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+// command is a node in the command tree. Leaf commands have run set; the
+// others group subcommands.
+type command struct {
+	name    string
+	args    string
+	summary string
+	nargs   [2]int
+	flags   []string
+	columns []string
+	run     func(ctx context.Context, inv *invocation) (interface{}, error)
+	// rows, if set, picks what to print from the result for the table, CSV
+	// and NDJSON formats. JSON output always shows the whole result.
+	rows func(v interface{}) interface{}
+	sub  []*command
+}
+
+// invocation is what a leaf command runs with.
+type invocation struct {
+	client *ebird.Client
+	args   []string
+	flags  *flagValues
+	opts   []ebird.RequestOption
+}
+
+var (
+	regionObsFlags = []string{"back", "cat", "hotspot", "include-provisional", "max-results", "r", "spp-locale"}
+	geoObsFlags    = []string{"lat", "lng", "dist", "back", "cat", "hotspot", "include-provisional", "max-results", "spp-locale"}
+	obsColumns     = []string{"obsDt", "speciesCode", "comName", "howMany", "locName", "subId"}
+	hotspotColumns = []string{"locId", "locName", "lat", "lng", "latestObsDt", "numSpeciesAllTime"}
+	feedColumns    = []string{"subId", "obsDt", "obsTime", "numSpecies", "userDisplayName", "locId"}
+	taxonColumns   = []string{"speciesCode", "comName", "sciName", "category", "familyComName"}
+)
+
+var commands = []*command{
+	{
+		name:    "obs",
+		summary: "Observations",
+		sub: []*command{
+			{
+				name:    "recent",
+				args:    "<region>",
+				summary: "Recent observations in a region",
+				nargs:   [2]int{1, 1},
+				flags:   regionObsFlags,
+				columns: obsColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.RecentObservationsInRegion(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "notable",
+				args:    "[region]",
+				summary: "Recent notable observations in a region, or near --lat/--lng",
+				nargs:   [2]int{0, 1},
+				flags:   []string{"lat", "lng", "dist", "back", "hotspot", "max-results", "r", "spp-locale"},
+				columns: obsColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					if len(inv.args) == 0 {
+						return inv.client.RecentNearbyNotableObservations(ctx, inv.opts...)
+					}
+					return inv.client.RecentNotableObservationsInRegion(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "species",
+				args:    "<region> <species>",
+				summary: "Recent observations of a species in a region",
+				nargs:   [2]int{2, 2},
+				flags:   []string{"back", "hotspot", "include-provisional", "max-results", "r", "spp-locale"},
+				columns: obsColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.RecentObservationsOfSpeciesInRegion(ctx, inv.args[0], inv.args[1], inv.opts...)
+				},
+			},
+			{
+				name:    "nearby",
+				args:    "[species]",
+				summary: "Recent observations near --lat/--lng, optionally of one species",
+				nargs:   [2]int{0, 1},
+				flags:   geoObsFlags,
+				columns: obsColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					if len(inv.args) == 0 {
+						return inv.client.RecentNearbyObservations(ctx, inv.opts...)
+					}
+					return inv.client.RecentNearbyObservationsOfSpecies(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "nearest",
+				args:    "<species>",
+				summary: "Nearest recent observations of a species to --lat/--lng",
+				nargs:   [2]int{1, 1},
+				flags:   geoObsFlags,
+				columns: obsColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.NearestObservationsOfSpecies(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "historic",
+				args:    "<region> <date>",
+				summary: "Observations in a region on a date, or from date to --to",
+				nargs:   [2]int{2, 2},
+				flags:   []string{"cat", "hotspot", "include-provisional", "max-results", "r", "spp-locale", "to", "concurrency"},
+				columns: obsColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					date, err := parseDate(inv.args[1])
+					if err != nil {
+						return nil, err
+					}
+					if inv.flags.to == "" {
+						return inv.client.HistoricObservationsOnDate(ctx, inv.args[0], date, inv.opts...)
+					}
+					to, err := parseDate(inv.flags.to)
+					if err != nil {
+						return nil, err
+					}
+					return inv.client.HistoricObservationsRange(ctx, inv.args[0], date, to, inv.opts...)
+				},
+			},
+		},
+	},
+	{
+		name:    "checklist",
+		summary: "Checklists",
+		sub: []*command{
+			{
+				name:    "feed",
+				args:    "<region> [date]",
+				summary: "Recent checklists in a region, or the checklists for a date",
+				nargs:   [2]int{1, 2},
+				flags:   []string{"max-results", "sort-key"},
+				columns: feedColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					if len(inv.args) == 1 {
+						return inv.client.RecentChecklistsFeed(ctx, inv.args[0], inv.opts...)
+					}
+					date, err := parseDate(inv.args[1])
+					if err != nil {
+						return nil, err
+					}
+					return inv.client.ChecklistFeedOnDate(ctx, inv.args[0], date, inv.opts...)
+				},
+			},
+			{
+				name:    "view",
+				args:    "<subId>",
+				summary: "Observations on a checklist",
+				nargs:   [2]int{1, 1},
+				columns: []string{"speciesCode", "howMany", "obsDt"},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.ViewChecklist(ctx, inv.args[0], inv.opts...)
+				},
+				rows: func(v interface{}) interface{} {
+					return v.(*ebird.ViewChecklist).Obs
+				},
+			},
+		},
+	},
+	{
+		name:    "product",
+		summary: "Regional statistics and rankings",
+		sub: []*command{
+			{
+				name:    "top100",
+				args:    "<region> <date>",
+				summary: "Top 100 contributors on a date",
+				nargs:   [2]int{2, 2},
+				flags:   []string{"ranked-by", "max-results"},
+				columns: []string{"rowNum", "userDisplayName", "numSpecies", "numCompleteChecklists"},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					date, err := parseDate(inv.args[1])
+					if err != nil {
+						return nil, err
+					}
+					return inv.client.Top100(ctx, inv.args[0], date, inv.opts...)
+				},
+			},
+			{
+				name:    "stats",
+				args:    "<region> <date>",
+				summary: "Checklist, contributor and species counts on a date",
+				nargs:   [2]int{2, 2},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					date, err := parseDate(inv.args[1])
+					if err != nil {
+						return nil, err
+					}
+					return inv.client.RegionalStatisticsOnDate(ctx, inv.args[0], date, inv.opts...)
+				},
+			},
+			{
+				name:    "species",
+				args:    "<region>",
+				summary: "Species codes ever reported in a region",
+				nargs:   [2]int{1, 1},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.SpeciesListForRegion(ctx, inv.args[0], inv.opts...)
+				},
+			},
+		},
+	},
+	{
+		name:    "hotspot",
+		summary: "Hotspots",
+		sub: []*command{
+			{
+				name:    "list",
+				args:    "<region>",
+				summary: "Hotspots in a region",
+				nargs:   [2]int{1, 1},
+				flags:   []string{"back"},
+				columns: hotspotColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.HotspotsInRegion(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "near",
+				summary: "Hotspots near --lat/--lng",
+				flags:   []string{"lat", "lng", "dist", "back"},
+				columns: hotspotColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.NearbyHotspots(ctx, inv.opts...)
+				},
+			},
+			{
+				name:    "info",
+				args:    "<locId>",
+				summary: "Details of a hotspot",
+				nargs:   [2]int{1, 1},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.HotspotInfo(ctx, inv.args[0], inv.opts...)
+				},
+			},
+		},
+	},
+	{
+		name:    "taxonomy",
+		summary: "Taxonomy",
+		sub: []*command{
+			{
+				name:    "list",
+				summary: "The eBird taxonomy",
+				flags:   []string{"cat", "locale", "species", "version"},
+				columns: taxonColumns,
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.EbirdTaxonomy(ctx, inv.opts...)
+				},
+			},
+			{
+				name:    "search",
+				args:    "<query>",
+				summary: "Find taxa by common, scientific or banding name",
+				nargs:   [2]int{1, 1},
+				flags:   []string{"locale", "version", "limit", "categories"},
+				columns: []string{"Taxon.speciesCode", "Taxon.comName", "Taxon.sciName", "Score", "Field"},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					tax, err := inv.client.LoadTaxonomy(ctx, inv.opts...)
+					if err != nil {
+						return nil, err
+					}
+					opts := []ebird.SearchOption{ebird.SearchLimit(inv.flags.limit)}
+					if categories := splitList(inv.flags.categories); len(categories) > 0 {
+						opts = append(opts, ebird.SearchCategories(categories...))
+					}
+					return tax.Search(inv.args[0], opts...), nil
+				},
+			},
+			{
+				name:    "forms",
+				args:    "<species>",
+				summary: "Subspecies and forms of a species",
+				nargs:   [2]int{1, 1},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.TaxonomicForms(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "groups",
+				args:    "<ebird|merlin>",
+				summary: "Taxonomic groups",
+				nargs:   [2]int{1, 1},
+				flags:   []string{"group-name-locale"},
+				columns: []string{"groupOrder", "groupName"},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.TaxonomicGroups(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "versions",
+				summary: "Taxonomy versions",
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.TaxonomyVersions(ctx, inv.opts...)
+				},
+			},
+			{
+				name:    "locales",
+				summary: "Locale codes for common names",
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.TaxaLocaleCodes(ctx, inv.opts...)
+				},
+			},
+		},
+	},
+	{
+		name:    "region",
+		summary: "Regions",
+		sub: []*command{
+			{
+				name:    "list",
+				args:    "<country|subnational1|subnational2> <parent>",
+				summary: "Subregions of a region",
+				nargs:   [2]int{2, 2},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.SubRegionList(ctx, inv.args[0], inv.args[1], inv.opts...)
+				},
+			},
+			{
+				name:    "info",
+				args:    "<region>",
+				summary: "Name and bounds of a region",
+				nargs:   [2]int{1, 1},
+				flags:   []string{"region-name-format", "delim"},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.RegionInfo(ctx, inv.args[0], inv.opts...)
+				},
+			},
+			{
+				name:    "adjacent",
+				args:    "<region>",
+				summary: "Regions that border a region",
+				nargs:   [2]int{1, 1},
+				run: func(ctx context.Context, inv *invocation) (interface{}, error) {
+					return inv.client.AdjacentRegions(ctx, inv.args[0])
+				},
+			},
+		},
+	},
+}
+
+func findCommand(cmds []*command, name string) *command {
+	for _, cmd := range cmds {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func (cmd *command) checkArgs(args []string) error {
+	if len(args) < cmd.nargs[0] || len(args) > cmd.nargs[1] {
+		if cmd.args == "" {
+			return fmt.Errorf("%s takes no arguments", cmd.name)
+		}
+		return fmt.Errorf("usage: %s %s", cmd.name, cmd.args)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// config is read from a JSON file such as:
+//
+//	{"apiKey": "abc123", "acceptLanguage": "fr"}
+//
+// EBIRD_API_KEY and EBIRD_BASE_URL take precedence over the file.
+type config struct {
+	APIKey         string `json:"apiKey"`
+	BaseURL        string `json:"baseURL"`
+	AcceptLanguage string `json:"acceptLanguage"`
+}
+
+// defaultConfigPath returns $EBIRD_CONFIG, or ebird/config.json in the
+// user's config directory.
+func defaultConfigPath(getenv func(string) string) string {
+	if path := getenv("EBIRD_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ebird", "config.json")
+}
+
+// loadConfig reads the config file at path and applies environment
+// overrides. A missing file is only an error if required is set.
+func loadConfig(path string, required bool, getenv func(string) string) (config, error) {
+	var cfg config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case errors.Is(err, os.ErrNotExist) && !required:
+		default:
+			return cfg, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if key := getenv("EBIRD_API_KEY"); key != "" {
+		cfg.APIKey = key
+	}
+	if baseURL := getenv("EBIRD_BASE_URL"); baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	return cfg, nil
+}
+
+// validate checks the settings that the client would otherwise panic on.
+func (c config) validate() error {
+	if c.BaseURL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL %q: %w", c.BaseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid base URL %q: must be an absolute URL", c.BaseURL)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+const dateLayout = "2006-01-02"
+
+// flagValues holds every flag a command can register. Only the flags named by
+// the command are defined on its FlagSet, and only those set on the command
+// line are turned into request options.
+type flagValues struct {
+	back               int
+	cat                string
+	dist               int
+	hotspot            bool
+	includeProvisional bool
+	lat                float64
+	lng                float64
+	locale             string
+	maxResults         int
+	r                  string
+	rankedBy           string
+	regionNameFormat   string
+	sortKey            string
+	species            string
+	sppLocale          string
+	groupNameLocale    string
+	version            string
+	delim              string
+
+	to          string
+	concurrency int
+	limit       int
+	categories  string
+
+	set map[string]bool
+}
+
+// optionFlag maps a command-line flag to the RequestOption it sets.
+type optionFlag struct {
+	name   string
+	define func(fs *flag.FlagSet, v *flagValues)
+	option func(v *flagValues) ebird.RequestOption
+}
+
+var optionFlags = []optionFlag{
+	{
+		name: "back",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.IntVar(&v.back, "back", 0, "number of days back to fetch (1-30)")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Back(v.back) },
+	},
+	{
+		name: "cat",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.cat, "cat", "", "comma-separated taxonomic categories")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Cat(v.cat) },
+	},
+	{
+		name:   "dist",
+		define: func(fs *flag.FlagSet, v *flagValues) { fs.IntVar(&v.dist, "dist", 0, "search radius in km (0-500)") },
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Dist(v.dist) },
+	},
+	{
+		name: "hotspot",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.BoolVar(&v.hotspot, "hotspot", false, "only include hotspots")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Hotspot(v.hotspot) },
+	},
+	{
+		name: "include-provisional",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.BoolVar(&v.includeProvisional, "include-provisional", false, "include unreviewed observations")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.IncludeProvisional(v.includeProvisional) },
+	},
+	{
+		name:   "lat",
+		define: func(fs *flag.FlagSet, v *flagValues) { fs.Float64Var(&v.lat, "lat", 0, "latitude") },
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Lat(v.lat) },
+	},
+	{
+		name:   "lng",
+		define: func(fs *flag.FlagSet, v *flagValues) { fs.Float64Var(&v.lng, "lng", 0, "longitude") },
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Lng(v.lng) },
+	},
+	{
+		name: "locale",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.locale, "locale", "", "locale for common names")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Locale(v.locale) },
+	},
+	{
+		name: "max-results",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.IntVar(&v.maxResults, "max-results", 0, "maximum number of results (1-100)")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.MaxResults(v.maxResults) },
+	},
+	{
+		name: "r",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.r, "r", "", "comma-separated locations (up to 10)")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.R(splitList(v.r)...) },
+	},
+	{
+		name: "ranked-by",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.rankedBy, "ranked-by", "", `rank by "spp" or "cl"`)
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.RankedBy(v.rankedBy) },
+	},
+	{
+		name: "region-name-format",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.regionNameFormat, "region-name-format", "", "region name format, such as full or nameonly")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.RegionNameFormat(v.regionNameFormat) },
+	},
+	{
+		name: "sort-key",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.sortKey, "sort-key", "", `sort by "obs_dt" or "creation_dt"`)
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.SortKey(v.sortKey) },
+	},
+	{
+		name: "species",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.species, "species", "", "comma-separated species codes")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Species(splitList(v.species)...) },
+	},
+	{
+		name: "spp-locale",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.sppLocale, "spp-locale", "", "locale for species names")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.SppLocale(v.sppLocale) },
+	},
+	{
+		name: "group-name-locale",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.groupNameLocale, "group-name-locale", "", "locale for group names")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.GroupNameLocale(v.groupNameLocale) },
+	},
+	{
+		name:   "version",
+		define: func(fs *flag.FlagSet, v *flagValues) { fs.StringVar(&v.version, "version", "", "taxonomy version") },
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Version(v.version) },
+	},
+	{
+		name: "delim",
+		define: func(fs *flag.FlagSet, v *flagValues) {
+			fs.StringVar(&v.delim, "delim", "", "delimiter for region names")
+		},
+		option: func(v *flagValues) ebird.RequestOption { return ebird.Delim(v.delim) },
+	},
+}
+
+// extraFlags are command flags that are not sent to the API.
+var extraFlags = map[string]func(fs *flag.FlagSet, v *flagValues){
+	"to": func(fs *flag.FlagSet, v *flagValues) {
+		fs.StringVar(&v.to, "to", "", "last date of a range (YYYY-MM-DD)")
+	},
+	"concurrency": func(fs *flag.FlagSet, v *flagValues) {
+		fs.IntVar(&v.concurrency, "concurrency", 0, "number of requests to run at once")
+	},
+	"limit": func(fs *flag.FlagSet, v *flagValues) {
+		fs.IntVar(&v.limit, "limit", 10, "maximum number of results")
+	},
+	"categories": func(fs *flag.FlagSet, v *flagValues) {
+		fs.StringVar(&v.categories, "categories", "", "comma-separated taxonomic categories to keep")
+	},
+}
+
+func (v *flagValues) define(fs *flag.FlagSet, names []string) {
+	for _, name := range names {
+		if define, ok := extraFlags[name]; ok {
+			define(fs, v)
+			continue
+		}
+		for _, f := range optionFlags {
+			if f.name == name {
+				f.define(fs, v)
+				break
+			}
+		}
+	}
+}
+
+// options returns the request options for the flags set on the command line.
+func (v *flagValues) options() []ebird.RequestOption {
+	var opts []ebird.RequestOption
+	for _, f := range optionFlags {
+		if v.set[f.name] {
+			opts = append(opts, f.option(v))
+		}
+	}
+	if v.set["concurrency"] {
+		opts = append(opts, ebird.Concurrency(v.concurrency))
+	}
+	return opts
+}
+
+// parseInterspersed parses fs allowing flags after positional arguments, so
+// that "obs recent US-NY --back 7" works as well as "obs recent --back 7 US-NY".
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func parseDate(value string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: want YYYY-MM-DD", value)
+	}
+	return t, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,172 @@
+// Command ebird queries the eBird API from the command line.
+//
+// Usage:
+//
+//	ebird <group> <command> [flags] [args]
+//
+// For example:
+//
+//	ebird obs recent US-NY-109 --back 7 --max-results 20
+//	ebird hotspot near --lat 42.45 --lng -76.50 -o json
+//	ebird taxonomy search "black-capped chickadee"
+//
+// The API key is read from EBIRD_API_KEY, or from the "apiKey" field of the
+// JSON config file given by --config, EBIRD_CONFIG, or ebird/config.json in
+// the user's config directory.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command in args and returns the process exit code: 0 on
+// success, 1 if the command failed and 2 for usage errors.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printGroups(stdout)
+		return 0
+	}
+
+	group := findCommand(commands, args[0])
+	if group == nil {
+		fmt.Fprintf(stderr, "ebird: unknown command %q\n\n", args[0])
+		printGroups(stderr)
+		return 2
+	}
+	if len(args) < 2 || args[1] == "help" || args[1] == "-h" || args[1] == "--help" {
+		printCommands(stdout, group)
+		return 0
+	}
+
+	cmd := findCommand(group.sub, args[1])
+	if cmd == nil {
+		fmt.Fprintf(stderr, "ebird: unknown command %q\n\n", group.name+" "+args[1])
+		printCommands(stderr, group)
+		return 2
+	}
+
+	path := group.name + " " + cmd.name
+	fs := flag.NewFlagSet("ebird "+path, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ebird %s [flags] %s\n\n%s.\n\nFlags:\n", path, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+
+	var (
+		output     string
+		columns    string
+		configPath string
+		strict     bool
+	)
+	fs.StringVar(&output, "o", "table", "output format: "+strings.Join(outputFormats, ", "))
+	fs.StringVar(&output, "output", "table", "output format: "+strings.Join(outputFormats, ", "))
+	fs.StringVar(&columns, "columns", "", "comma-separated columns for table and csv output")
+	fs.StringVar(&configPath, "config", defaultConfigPath(getenv), "path to a JSON config file")
+	fs.BoolVar(&strict, "strict", false, "reject invalid or unsupported options instead of ignoring them")
+
+	values := &flagValues{set: map[string]bool{}}
+	values.define(fs, cmd.flags)
+
+	positional, err := parseInterspersed(fs, args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		return 2
+	}
+	fs.Visit(func(f *flag.Flag) {
+		values.set[f.Name] = true
+	})
+	if err := cmd.checkArgs(positional); err != nil {
+		fmt.Fprintf(stderr, "ebird %s: %v\n", group.name, err)
+		return 2
+	}
+
+	cfg, err := loadConfig(configPath, values.set["config"], getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "ebird: %v\n", err)
+		return 1
+	}
+	if err := cfg.validate(); err != nil {
+		fmt.Fprintf(stderr, "ebird: %v\n", err)
+		return 2
+	}
+
+	client, err := newClient(cfg, strict)
+	if err != nil {
+		fmt.Fprintf(stderr, "ebird: %v\n", err)
+		return 1
+	}
+
+	result, err := cmd.run(ctx, &invocation{
+		client: client,
+		args:   positional,
+		flags:  values,
+		opts:   values.options(),
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "ebird %s: %v\n", path, err)
+		return 1
+	}
+
+	if cmd.rows != nil && output != "json" {
+		result = cmd.rows(result)
+	}
+	cols := splitList(columns)
+	if len(cols) == 0 && output == "table" {
+		cols = cmd.columns
+	}
+	if err := render(stdout, output, result, cols); err != nil {
+		fmt.Fprintf(stderr, "ebird: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func newClient(cfg config, strict bool) (*ebird.Client, error) {
+	var opts []ebird.ClientOption
+	if cfg.BaseURL != "" {
+		opts = append(opts, ebird.WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.AcceptLanguage != "" {
+		opts = append(opts, ebird.WithAcceptLanguage(cfg.AcceptLanguage))
+	}
+	if strict {
+		opts = append(opts, ebird.WithStrictOptions())
+	}
+	return ebird.NewClient(cfg.APIKey, opts...)
+}
+
+func printGroups(w io.Writer) {
+	fmt.Fprintf(w, "Usage: ebird <command> <subcommand> [flags] [args]\n\nCommands:\n")
+	for _, group := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", group.name, group.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"ebird <command>\" to list its subcommands.\n")
+}
+
+func printCommands(w io.Writer, group *command) {
+	fmt.Fprintf(w, "Usage: ebird %s <subcommand> [flags] [args]\n\nSubcommands:\n", group.name)
+	for _, cmd := range group.sub {
+		usage := strings.TrimSpace(cmd.name + " " + cmd.args)
+		fmt.Fprintf(w, "  %-45s %s\n", usage, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"ebird %s <subcommand> -h\" for its flags.\n", group.name)
+}
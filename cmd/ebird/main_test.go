@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantPath  string
+		wantQuery string
+		input     string
+		wantOut   string
+		wantCode  int
+	}{
+		{
+			name:      "obs recent table",
+			args:      []string{"obs", "recent", "US-NY", "--back", "7", "--max-results", "2"},
+			wantPath:  "/data/obs/US-NY/recent",
+			wantQuery: "back=7&maxResults=2",
+			input:     `[{"speciesCode":"amecro","comName":"American Crow","howMany":3,"locName":"Park","obsDt":"2024-05-01 08:00","subId":"S1"}]`,
+			wantOut:   "OBSDT             SPECIESCODE  COMNAME        HOWMANY  LOCNAME  SUBID\n2024-05-01 08:00  amecro       American Crow  3        Park     S1\n",
+		},
+		{
+			name:     "obs notable nearby ndjson",
+			args:     []string{"obs", "notable", "--lat", "42.45", "--lng", "-76.5", "-o", "ndjson"},
+			wantPath: "/data/obs/geo/recent/notable",
+			input:    `[{"speciesCode":"a"},{"speciesCode":"b"}]`,
+			wantOut:  "{\"speciesCode\":\"a\"}\n{\"speciesCode\":\"b\"}\n",
+		},
+		{
+			name:     "hotspot near csv",
+			args:     []string{"hotspot", "near", "--lat", "42.45", "--lng", "-76.5", "-o", "csv", "--columns", "locId,locName"},
+			wantPath: "/ref/hotspot/geo",
+			input:    `[{"locId":"L1","locName":"Sapsucker Woods","lat":42.47,"lng":-76.45}]`,
+			wantOut:  "locId,locName\nL1,Sapsucker Woods\n",
+		},
+		{
+			name:     "checklist view json",
+			args:     []string{"checklist", "view", "S1", "-o", "json"},
+			wantPath: "/product/checklist/view/S1",
+			input:    `{"subId":"S1","numSpecies":1}`,
+			wantOut:  "{\n  \"subId\": \"S1\",\n  \"numSpecies\": 1\n}\n",
+		},
+		{
+			name:     "product species",
+			args:     []string{"product", "species", "US-NY"},
+			wantPath: "/product/spplist/US-NY",
+			input:    `["amecro","blujay"]`,
+			wantOut:  "VALUE\namecro\nblujay\n",
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"obs", "bogus"},
+			wantCode: 2,
+		},
+		{
+			name:     "missing argument",
+			args:     []string{"obs", "recent"},
+			wantCode: 2,
+		},
+		{
+			name:     "bad date",
+			args:     []string{"obs", "historic", "US-NY", "yesterday"},
+			wantCode: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "test-api-key", r.Header.Get("X-eBirdApiToken"))
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+				if tt.wantQuery != "" {
+					assert.Equal(t, tt.wantQuery, r.URL.RawQuery)
+				}
+				w.Write([]byte(tt.input))
+			}))
+			defer server.Close()
+
+			env := map[string]string{
+				"EBIRD_API_KEY":  "test-api-key",
+				"EBIRD_BASE_URL": server.URL + "/",
+				"EBIRD_CONFIG":   filepath.Join(t.TempDir(), "missing.json"),
+			}
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tt.args, &stdout, &stderr, func(k string) string { return env[k] })
+
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+			if tt.wantCode == 0 {
+				assert.Equal(t, tt.wantOut, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunConfigFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "file-key", r.Header.Get("X-eBirdApiToken"))
+		assert.Equal(t, "fr", r.Header.Get("Accept-Language"))
+		w.Write([]byte(`[{"code":"US-NY-001","name":"Albany"}]`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"apiKey":"file-key","acceptLanguage":"fr","baseURL":"`+server.URL+`/"}`), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"region", "list", "subnational2", "US-NY", "--config", path}, &stdout, &stderr, func(string) string { return "" })
+	require.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, "CODE       NAME\nUS-NY-001  Albany\n", stdout.String())
+
+	code = run(context.Background(), []string{"region", "list", "subnational2", "US-NY", "--config", path + ".missing"}, &stdout, &stderr, func(string) string { return "" })
+	assert.Equal(t, 1, code)
+	assert.True(t, strings.Contains(stderr.String(), "config"))
+}
+
+func TestRunInvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"http://[::1", "api.ebird.org/v2/"} {
+		env := map[string]string{
+			"EBIRD_API_KEY":  "test-api-key",
+			"EBIRD_BASE_URL": baseURL,
+			"EBIRD_CONFIG":   filepath.Join(t.TempDir(), "missing.json"),
+		}
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"obs", "recent", "US-NY"}, &stdout, &stderr, func(k string) string { return env[k] })
+		assert.Equal(t, 2, code, baseURL)
+		assert.Contains(t, stderr.String(), "invalid base URL")
+	}
+}
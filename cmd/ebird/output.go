@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var outputFormats = []string{"table", "json", "csv", "ndjson"}
+
+// render writes v, a struct, a pointer to a struct or a slice of either, in
+// format. A slice of strings is written one value per row. For table and CSV
+// output, nested structs are flattened into dotted columns and slices are
+// left out; columns limits and orders the columns by their JSON names.
+func render(w io.Writer, format string, v interface{}, columns []string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		for _, item := range items(v) {
+			if err := enc.Encode(item.Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "table", "csv":
+		header, rows := tabulate(v, columns)
+		if format == "csv" {
+			cw := csv.NewWriter(w)
+			cw.Write(header)
+			cw.WriteAll(rows)
+			return cw.Error()
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown output format %q: want one of %s", format, strings.Join(outputFormats, ", "))
+}
+
+// items returns the elements of v if it is a slice, or v itself.
+func items(v interface{}) []reflect.Value {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+			return nil
+		}
+		return []reflect.Value{rv}
+	}
+	out := make([]reflect.Value, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i)
+	}
+	return out
+}
+
+func tabulate(v interface{}, columns []string) ([]string, [][]string) {
+	var header []string
+	var rows [][]string
+	for i, item := range items(v) {
+		item = reflect.Indirect(item)
+		if item.Kind() != reflect.Struct {
+			if i == 0 {
+				header = []string{"value"}
+			}
+			rows = append(rows, []string{formatValue(item)})
+			continue
+		}
+
+		var names, values []string
+		flatten(item, "", &names, &values)
+		if i == 0 {
+			header = names
+			if len(columns) > 0 {
+				header = columns
+			}
+		}
+		byName := make(map[string]string, len(names))
+		for j, name := range names {
+			byName[name] = values[j]
+		}
+		row := make([]string, len(header))
+		for j, name := range header {
+			row[j] = byName[name]
+		}
+		rows = append(rows, row)
+	}
+	if header == nil {
+		header = columns
+	}
+	return header, rows
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// flatten appends the JSON name and formatted value of every scalar field of
+// v. Embedded structs are promoted and other nested structs are prefixed with
+// their field name.
+func flatten(v reflect.Value, prefix string, names, values *[]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fv := v.Field(i)
+		switch {
+		case field.Anonymous && fv.Kind() == reflect.Struct:
+			flatten(fv, prefix, names, values)
+		case fv.Kind() == reflect.Struct && fv.Type() != timeType:
+			flatten(fv, prefix+name+".", names, values)
+		case fv.Kind() == reflect.Slice || fv.Kind() == reflect.Map:
+		default:
+			*names = append(*names, prefix+name)
+			*values = append(*values, formatValue(fv))
+		}
+	}
+}
+
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(dateLayout)
+	}
+	return fmt.Sprint(v.Interface())
+}
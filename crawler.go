@@ -0,0 +1,308 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const crawlDateLayout = "2006-01-02"
+
+// ErrFeedTruncated is reported for a day whose checklist feed filled a whole
+// page, so that checklists beyond the page may be missing. The day is not
+// recorded as completed.
+var ErrFeedTruncated = errors.New("ebird: checklist feed truncated")
+
+// CrawlState is the resumable state of a ChecklistCrawler. It can be
+// marshaled to JSON and passed back with WithCrawlState to continue a
+// backfill.
+type CrawlState struct {
+	Seen           []string `json:"seen"`
+	CompletedDates []string `json:"completedDates"`
+}
+
+// CrawlProgress is reported after each checklist of a feed is handled.
+type CrawlProgress struct {
+	Date     time.Time
+	FeedSize int
+	Fetched  int
+	Skipped  int
+	Failed   int
+}
+
+// CrawlResult holds one fetched checklist, or the error that prevented a
+// checklist or a whole feed from being fetched. A result with Err wrapping
+// ErrFeedTruncated and no SubId reports a feed that may be incomplete.
+type CrawlResult struct {
+	Date      time.Time
+	SubId     string
+	Checklist *ViewChecklist
+	Err       error
+}
+
+// ChecklistCrawler expands checklist feeds into full checklists, fetching
+// each SubId at most once across crawls.
+type ChecklistCrawler struct {
+	client      *Client
+	concurrency int
+	onProgress  func(CrawlProgress)
+
+	mu        sync.Mutex
+	seen      map[string]bool
+	completed map[string]bool
+}
+
+type CrawlerOption func(*ChecklistCrawler)
+
+func WithCrawlConcurrency(n int) CrawlerOption {
+	return func(cr *ChecklistCrawler) {
+		if n > 0 {
+			cr.concurrency = n
+		}
+	}
+}
+
+func WithCrawlProgress(fn func(CrawlProgress)) CrawlerOption {
+	return func(cr *ChecklistCrawler) {
+		cr.onProgress = fn
+	}
+}
+
+func WithCrawlState(state CrawlState) CrawlerOption {
+	return func(cr *ChecklistCrawler) {
+		for _, subId := range state.Seen {
+			cr.seen[subId] = true
+		}
+		for _, date := range state.CompletedDates {
+			cr.completed[date] = true
+		}
+	}
+}
+
+// feedPageSize requests the largest page the feed endpoints allow. It is
+// applied before the caller's options so that MaxResults still overrides it.
+func feedPageSize(o *RequestOptions) {
+	o.URLParams.Set("maxResults", "200")
+}
+
+func NewChecklistCrawler(client *Client, opts ...CrawlerOption) *ChecklistCrawler {
+	cr := &ChecklistCrawler{
+		client:      client,
+		concurrency: defaultConcurrency,
+		seen:        make(map[string]bool),
+		completed:   make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(cr)
+	}
+	return cr
+}
+
+// State returns a snapshot of the SubIds fetched and the dates completed so
+// far.
+func (cr *ChecklistCrawler) State() CrawlState {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	state := CrawlState{
+		Seen:           make([]string, 0, len(cr.seen)),
+		CompletedDates: make([]string, 0, len(cr.completed)),
+	}
+	for subId, done := range cr.seen {
+		if done {
+			state.Seen = append(state.Seen, subId)
+		}
+	}
+	for date := range cr.completed {
+		state.CompletedDates = append(state.CompletedDates, date)
+	}
+	sort.Strings(state.Seen)
+	sort.Strings(state.CompletedDates)
+	return state
+}
+
+// Crawl walks ChecklistFeedOnDate for each day from from to to inclusive and
+// fetches every checklist not seen before. Days already completed in the
+// crawler's state are skipped. Request options apply to the feed calls; the
+// feed defaults to its maximum page size of 200. A day whose feed fills the
+// page is reported with ErrFeedTruncated and is not marked completed. The
+// channel is closed when the range is done or ctx is cancelled.
+func (cr *ChecklistCrawler) Crawl(ctx context.Context, regionCode string, from, to time.Time, opts ...RequestOption) (<-chan CrawlResult, error) {
+	days, err := dateRange(regionCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]RequestOption{feedPageSize}, opts...)
+	pageSize, _ := strconv.Atoi(processOptions(opts...).URLParams.Get("maxResults"))
+	out := make(chan CrawlResult)
+
+	go func() {
+		defer close(out)
+		for _, date := range days {
+			key := date.Format(crawlDateLayout)
+			if cr.isCompleted(key) {
+				continue
+			}
+
+			feed, err := cr.client.ChecklistFeedOnDate(ctx, regionCode, date, opts...)
+			if err != nil {
+				if !sendCrawlResult(ctx, out, CrawlResult{Date: date, Err: err}) {
+					return
+				}
+				continue
+			}
+
+			truncated := pageSize > 0 && len(feed) >= pageSize
+			if truncated {
+				err := fmt.Errorf("%w: %d checklists on %s", ErrFeedTruncated, len(feed), key)
+				if !sendCrawlResult(ctx, out, CrawlResult{Date: date, Err: err}) {
+					return
+				}
+			}
+
+			subIds := make([]string, 0, len(feed))
+			for _, f := range feed {
+				subIds = append(subIds, f.SubId)
+			}
+			if cr.fetchAll(ctx, date, subIds, out) && !truncated {
+				cr.markCompleted(key)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// CrawlRecent fetches every checklist in RecentChecklistsFeed for a region
+// that has not been seen before.
+func (cr *ChecklistCrawler) CrawlRecent(ctx context.Context, regionCode string, opts ...RequestOption) (<-chan CrawlResult, error) {
+	feed, err := cr.client.RecentChecklistsFeed(ctx, regionCode, append([]RequestOption{feedPageSize}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	subIds := make([]string, 0, len(feed))
+	for _, f := range feed {
+		subIds = append(subIds, f.SubId)
+	}
+
+	out := make(chan CrawlResult)
+	go func() {
+		defer close(out)
+		cr.fetchAll(ctx, time.Time{}, subIds, out)
+	}()
+	return out, nil
+}
+
+// fetchAll fetches the unseen checklists of one feed and reports whether
+// all of them succeeded. A SubId is only marked seen once its checklist has
+// been delivered on out.
+func (cr *ChecklistCrawler) fetchAll(ctx context.Context, date time.Time, subIds []string, out chan<- CrawlResult) bool {
+	progress := CrawlProgress{Date: date, FeedSize: len(subIds)}
+	var progressMu sync.Mutex
+	report := func(update func(*CrawlProgress)) {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		update(&progress)
+		if cr.onProgress != nil {
+			cr.onProgress(progress)
+		}
+	}
+
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < cr.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for subId := range queue {
+				checklist, err := cr.client.ViewChecklist(ctx, subId)
+				if err != nil {
+					cr.release(subId)
+					report(func(p *CrawlProgress) { p.Failed++ })
+				} else {
+					report(func(p *CrawlProgress) { p.Fetched++ })
+				}
+				if !sendCrawlResult(ctx, out, CrawlResult{Date: date, SubId: subId, Checklist: checklist, Err: err}) {
+					cr.release(subId)
+					return
+				}
+				if err == nil {
+					cr.markSeen(subId)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, subId := range subIds {
+		if cr.claim(subId) {
+			report(func(p *CrawlProgress) { p.Skipped++ })
+			continue
+		}
+		select {
+		case queue <- subId:
+		case <-ctx.Done():
+			cr.release(subId)
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return ctx.Err() == nil && progress.Failed == 0
+}
+
+// claim reports whether subId was already seen. Unseen SubIds are marked as
+// in flight so that duplicates within one feed are skipped too.
+func (cr *ChecklistCrawler) claim(subId string) bool {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	if _, ok := cr.seen[subId]; ok {
+		return true
+	}
+	cr.seen[subId] = false
+	return false
+}
+
+func (cr *ChecklistCrawler) markSeen(subId string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.seen[subId] = true
+}
+
+func (cr *ChecklistCrawler) release(subId string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	delete(cr.seen, subId)
+}
+
+func (cr *ChecklistCrawler) isCompleted(date string) bool {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	return cr.completed[date]
+}
+
+func (cr *ChecklistCrawler) markCompleted(date string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.completed[date] = true
+}
+
+func sendCrawlResult(ctx context.Context, out chan<- CrawlResult, result CrawlResult) bool {
+	select {
+	case out <- result:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
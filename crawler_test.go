@@ -0,0 +1,221 @@
+package ebird
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCrawlerServer(t *testing.T, feeds map[string]string, failing map[string]bool) (*httptest.Server, *sync.Map) {
+	var viewed sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/product/lists/"):
+			assert.Equal(t, "200", r.URL.Query().Get("maxResults"))
+			feed, ok := feeds[strings.TrimPrefix(r.URL.Path, "/product/lists/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(feed))
+		case strings.HasPrefix(r.URL.Path, "/product/checklist/view/"):
+			subId := strings.TrimPrefix(r.URL.Path, "/product/checklist/view/")
+			n, _ := viewed.LoadOrStore(subId, new(int))
+			*n.(*int)++
+			if failing[subId] {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(ViewChecklist{SubId: subId, Obs: []Observation{{SpeciesCode: "amecro"}}})
+		}
+	}))
+	return server, &viewed
+}
+
+func collectCrawl(t *testing.T, results <-chan CrawlResult) (ok []string, failed []string) {
+	for r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.SubId)
+			continue
+		}
+		require.NotNil(t, r.Checklist)
+		assert.Equal(t, r.SubId, r.Checklist.SubId)
+		assert.Len(t, r.Checklist.Obs, 1)
+		ok = append(ok, r.SubId)
+	}
+	sort.Strings(ok)
+	sort.Strings(failed)
+	return ok, failed
+}
+
+func TestChecklistCrawler(t *testing.T) {
+	feeds := map[string]string{
+		"US-NY/2024/5/1": `[{"subId":"S1"},{"subId":"S2"},{"subId":"S2"}]`,
+		"US-NY/2024/5/2": `[{"subId":"S2"},{"subId":"S3"},{"subId":"S4"}]`,
+	}
+	server, viewed := newCrawlerServer(t, feeds, map[string]bool{"S4": true})
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var progress []CrawlProgress
+	crawler := NewChecklistCrawler(client, WithCrawlConcurrency(2), WithCrawlProgress(func(p CrawlProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, p)
+	}))
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	results, err := crawler.Crawl(context.Background(), "US-NY", from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+
+	ok, failed := collectCrawl(t, results)
+	assert.Equal(t, []string{"S1", "S2", "S3"}, ok)
+	assert.Equal(t, []string{"S4"}, failed)
+
+	n, _ := viewed.Load("S2")
+	assert.Equal(t, 1, *n.(*int), "S2 should be fetched once")
+
+	state := crawler.State()
+	assert.Equal(t, []string{"S1", "S2", "S3"}, state.Seen)
+	assert.Equal(t, []string{"2024-05-01"}, state.CompletedDates)
+
+	last := progress[len(progress)-1]
+	assert.Equal(t, CrawlProgress{Date: from.AddDate(0, 0, 1), FeedSize: 3, Fetched: 1, Skipped: 1, Failed: 1}, last)
+
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	var restored CrawlState
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	resumed := NewChecklistCrawler(client, WithCrawlState(restored))
+	results, err = resumed.Crawl(context.Background(), "US-NY", from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	ok, failed = collectCrawl(t, results)
+	assert.Empty(t, ok)
+	assert.Equal(t, []string{"S4"}, failed)
+
+	n, _ = viewed.Load("S1")
+	assert.Equal(t, 1, *n.(*int), "completed dates should not be crawled again")
+	n, _ = viewed.Load("S4")
+	assert.Equal(t, 2, *n.(*int), "failed checklists should be retried")
+}
+
+func TestChecklistCrawlerFeedError(t *testing.T) {
+	server, _ := newCrawlerServer(t, map[string]string{"US-NY/2024/5/2": `[{"subId":"S9"}]`}, nil)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	results, err := NewChecklistCrawler(client).Crawl(context.Background(), "US-NY", from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+
+	var got []CrawlResult
+	for r := range results {
+		got = append(got, r)
+	}
+	require.Len(t, got, 2)
+	assert.ErrorIs(t, got[0].Err, ErrNotFound)
+	assert.Equal(t, from, got[0].Date)
+	assert.Equal(t, "S9", got[1].SubId)
+	assert.NoError(t, got[1].Err)
+}
+
+func TestChecklistCrawlerRecent(t *testing.T) {
+	server, _ := newCrawlerServer(t, map[string]string{"US-NY": `[{"subId":"S1"},{"subId":"S2"}]`}, nil)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	crawler := NewChecklistCrawler(client, WithCrawlState(CrawlState{Seen: []string{"S1"}}))
+	results, err := crawler.CrawlRecent(context.Background(), "US-NY")
+	require.NoError(t, err)
+
+	ok, failed := collectCrawl(t, results)
+	assert.Equal(t, []string{"S2"}, ok)
+	assert.Empty(t, failed)
+
+	_, err = crawler.CrawlRecent(context.Background(), "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestChecklistCrawlerCancelled(t *testing.T) {
+	server, _ := newCrawlerServer(t, map[string]string{"US-NY/2024/5/1": `[{"subId":"S1"},{"subId":"S2"},{"subId":"S3"},{"subId":"S4"}]`}, nil)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	crawler := NewChecklistCrawler(client, WithCrawlConcurrency(4))
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	results, err := crawler.Crawl(ctx, "US-NY", from, from)
+	require.NoError(t, err)
+
+	first := <-results
+	require.NoError(t, first.Err)
+	cancel()
+	delivered := []string{first.SubId}
+	for r := range results {
+		if r.Err == nil {
+			delivered = append(delivered, r.SubId)
+		}
+	}
+	sort.Strings(delivered)
+
+	state := crawler.State()
+	assert.Equal(t, delivered, state.Seen, "only delivered checklists should be seen")
+	assert.Empty(t, state.CompletedDates)
+}
+
+func TestChecklistCrawlerTruncatedFeed(t *testing.T) {
+	feed := make([]ChecklistFeedOnDate, 200)
+	for i := range feed {
+		feed[i].SubId = "S" + strconv.Itoa(i)
+	}
+	data, err := json.Marshal(feed)
+	require.NoError(t, err)
+
+	server, _ := newCrawlerServer(t, map[string]string{"US-NY/2024/5/1": string(data)}, nil)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	crawler := NewChecklistCrawler(client, WithCrawlConcurrency(8))
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	results, err := crawler.Crawl(context.Background(), "US-NY", from, from)
+	require.NoError(t, err)
+
+	var fetched int
+	var truncated []error
+	for r := range results {
+		switch {
+		case r.Err != nil:
+			truncated = append(truncated, r.Err)
+		default:
+			fetched++
+		}
+	}
+	assert.Equal(t, 200, fetched)
+	require.Len(t, truncated, 1)
+	assert.ErrorIs(t, truncated[0], ErrFeedTruncated)
+	assert.Empty(t, crawler.State().CompletedDates)
+}
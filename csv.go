@@ -0,0 +1,236 @@
+package ebird
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func isCSV(params RequestOptions) bool {
+	return params.URLParams.Get("fmt") == "csv"
+}
+
+func decodeCSV(body []byte, result interface{}) error {
+	var err error
+	switch v := result.(type) {
+	case *[]HotspotInRegion:
+		*v, err = parseHotspotsInRegionCSV(bytes.NewReader(body))
+	case *[]NearbyHotspot:
+		*v, err = parseNearbyHotspotsCSV(bytes.NewReader(body))
+	case *[]EbirdTaxon:
+		*v, err = parseTaxonomyCSV(bytes.NewReader(body))
+	case *[]AdjacentRegion:
+		*v, err = parseAdjacentRegionsCSV(bytes.NewReader(body))
+	default:
+		return fmt.Errorf("CSV format is not supported for %T", result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode CSV response: %w", err)
+	}
+	return nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+// Hotspot CSV rows have no header and use the column order:
+// locId, countryCode, subnational1Code, subnational2Code, lat, lng, locName,
+// latestObsDt, numSpeciesAllTime. The last two columns are omitted for
+// hotspots that have never been birded.
+type hotspotCSVRow struct {
+	locId             string
+	countryCode       string
+	subnational1Code  string
+	subnational2Code  string
+	lat               float64
+	lng               float64
+	locName           string
+	latestObsDt       string
+	numSpeciesAllTime int
+}
+
+func parseHotspotCSV(r io.Reader) ([]hotspotCSVRow, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]hotspotCSVRow, 0, len(records))
+	for i, record := range records {
+		if len(record) < 7 {
+			return nil, fmt.Errorf("line %d: expected at least 7 fields, got %d", i+1, len(record))
+		}
+
+		row := hotspotCSVRow{
+			locId:            record[0],
+			countryCode:      record[1],
+			subnational1Code: record[2],
+			subnational2Code: record[3],
+			locName:          record[6],
+		}
+		if row.lat, err = strconv.ParseFloat(record[4], 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid latitude %q", i+1, record[4])
+		}
+		if row.lng, err = strconv.ParseFloat(record[5], 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid longitude %q", i+1, record[5])
+		}
+		if len(record) > 7 {
+			row.latestObsDt = record[7]
+		}
+		if len(record) > 8 && record[8] != "" {
+			if row.numSpeciesAllTime, err = strconv.Atoi(record[8]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid species count %q", i+1, record[8])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseHotspotsInRegionCSV(r io.Reader) ([]HotspotInRegion, error) {
+	rows, err := parseHotspotCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hotspots := make([]HotspotInRegion, 0, len(rows))
+	for _, row := range rows {
+		hotspots = append(hotspots, HotspotInRegion{
+			LocId:             row.locId,
+			LocName:           row.locName,
+			CountryCode:       row.countryCode,
+			Subnational1Code:  row.subnational1Code,
+			Subnational2Code:  row.subnational2Code,
+			Lat:               row.lat,
+			Lng:               row.lng,
+			LatestObsDt:       row.latestObsDt,
+			NumSpeciesAllTime: row.numSpeciesAllTime,
+		})
+	}
+	return hotspots, nil
+}
+
+func parseNearbyHotspotsCSV(r io.Reader) ([]NearbyHotspot, error) {
+	rows, err := parseHotspotCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hotspots := make([]NearbyHotspot, 0, len(rows))
+	for _, row := range rows {
+		hotspots = append(hotspots, NearbyHotspot{
+			LocId:             row.locId,
+			LocName:           row.locName,
+			CountryCode:       row.countryCode,
+			Subnational1Code:  row.subnational1Code,
+			Subnational2Code:  row.subnational2Code,
+			Lat:               row.lat,
+			Lng:               row.lng,
+			LatestObsDt:       row.latestObsDt,
+			NumSpeciesAllTime: row.numSpeciesAllTime,
+		})
+	}
+	return hotspots, nil
+}
+
+// parseTaxonomyCSV reads the taxonomy CSV export. Columns are matched by
+// header name so extra or reordered columns are tolerated.
+func parseTaxonomyCSV(r io.Reader) ([]EbirdTaxon, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["SPECIES_CODE"]; !ok {
+		return nil, errors.New("missing SPECIES_CODE column")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	taxonomy := make([]EbirdTaxon, 0, len(records)-1)
+	for i, record := range records[1:] {
+		taxon := EbirdTaxon{
+			SciName:       field(record, "SCIENTIFIC_NAME"),
+			ComName:       field(record, "COMMON_NAME"),
+			SpeciesCode:   field(record, "SPECIES_CODE"),
+			Category:      field(record, "CATEGORY"),
+			BandingCodes:  splitCodes(field(record, "BANDING_CODES")),
+			ComNameCodes:  splitCodes(field(record, "COM_NAME_CODES")),
+			SciNameCodes:  splitCodes(field(record, "SCI_NAME_CODES")),
+			Order:         field(record, "ORDER"),
+			FamilyCode:    field(record, "FAMILY_CODE"),
+			FamilyComName: field(record, "FAMILY_COM_NAME"),
+			FamilySciName: field(record, "FAMILY_SCI_NAME"),
+		}
+		if order := field(record, "TAXON_ORDER"); order != "" {
+			if taxon.TaxonOrder, err = strconv.ParseFloat(order, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid taxon order %q", i+2, order)
+			}
+		}
+		taxonomy = append(taxonomy, taxon)
+	}
+	return taxonomy, nil
+}
+
+func splitCodes(s string) []string {
+	codes := strings.Fields(s)
+	if len(codes) == 0 {
+		return nil
+	}
+	return codes
+}
+
+func parseAdjacentRegionsCSV(r io.Reader) ([]AdjacentRegion, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]AdjacentRegion, 0, len(records))
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "code") {
+			continue
+		}
+		region := AdjacentRegion{Code: record[0]}
+		if len(record) > 1 {
+			region.Name = record[1]
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
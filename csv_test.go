@@ -0,0 +1,160 @@
+package ebird
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hotspotsCSV = `L4466534,CA,CA-AB,CA-AB-TW,50.2252452,-111.6423368,12 Mile Coulee Reservoir,2023-09-20 09:00,80
+L18410127,CA,CA-AB,CA-AB-SI,50.594727,-113.767492,"160 Street Marsh, south end",2023-05-13 14:49,46
+L999,CA,CA-AB,CA-AB-SI,50.5,-113.7,Unbirded Spot
+`
+	taxonomyCSV = `SCIENTIFIC_NAME,COMMON_NAME,SPECIES_CODE,CATEGORY,TAXON_ORDER,COM_NAME_CODES,SCI_NAME_CODES,BANDING_CODES,ORDER,FAMILY_COM_NAME,FAMILY_SCI_NAME,REPORT_AS,EXTINCT,EXTINCT_YEAR,FAMILY_CODE
+Struthio camelus,Common Ostrich,ostric2,species,1.0,COOS,STCA,,Struthioniformes,Ostriches,Struthionidae,,,,struth1
+Poecile atricapillus,Black-capped Chickadee,bkcchi,species,31234.0,BCCH,POAT,BCCH,Passeriformes,"Tits, Chickadees, and Titmice",Paridae,,,,parida1
+`
+)
+
+func TestParseHotspotCSV(t *testing.T) {
+	hotspots, err := parseHotspotsInRegionCSV(strings.NewReader(hotspotsCSV))
+	require.NoError(t, err)
+	assert.Equal(t, []HotspotInRegion{
+		{LocId: "L4466534", LocName: "12 Mile Coulee Reservoir", CountryCode: "CA", Subnational1Code: "CA-AB", Subnational2Code: "CA-AB-TW", Lat: 50.2252452, Lng: -111.6423368, LatestObsDt: "2023-09-20 09:00", NumSpeciesAllTime: 80},
+		{LocId: "L18410127", LocName: "160 Street Marsh, south end", CountryCode: "CA", Subnational1Code: "CA-AB", Subnational2Code: "CA-AB-SI", Lat: 50.594727, Lng: -113.767492, LatestObsDt: "2023-05-13 14:49", NumSpeciesAllTime: 46},
+		{LocId: "L999", LocName: "Unbirded Spot", CountryCode: "CA", Subnational1Code: "CA-AB", Subnational2Code: "CA-AB-SI", Lat: 50.5, Lng: -113.7},
+	}, hotspots)
+
+	_, err = parseNearbyHotspotsCSV(strings.NewReader("L1,US,US-NY,,north,-76.0,Somewhere\n"))
+	assert.Error(t, err)
+
+	_, err = parseNearbyHotspotsCSV(strings.NewReader("L1,US,US-NY\n"))
+	assert.Error(t, err)
+}
+
+func TestParseTaxonomyCSV(t *testing.T) {
+	taxonomy, err := parseTaxonomyCSV(strings.NewReader(taxonomyCSV))
+	require.NoError(t, err)
+	require.Len(t, taxonomy, 2)
+	assert.Equal(t, EbirdTaxon{
+		SciName:       "Poecile atricapillus",
+		ComName:       "Black-capped Chickadee",
+		SpeciesCode:   "bkcchi",
+		Category:      "species",
+		TaxonOrder:    31234,
+		BandingCodes:  []string{"BCCH"},
+		ComNameCodes:  []string{"BCCH"},
+		SciNameCodes:  []string{"POAT"},
+		Order:         "Passeriformes",
+		FamilyCode:    "parida1",
+		FamilyComName: "Tits, Chickadees, and Titmice",
+		FamilySciName: "Paridae",
+	}, taxonomy[1])
+	assert.Nil(t, taxonomy[0].BandingCodes)
+
+	_, err = parseTaxonomyCSV(strings.NewReader("COMMON_NAME\nOstrich\n"))
+	assert.Error(t, err)
+}
+
+func TestCSVResponses(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "csv", r.URL.Query().Get("fmt"))
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("HotspotsInRegion", func(t *testing.T) {
+		body = hotspotsCSV
+		hotspots, err := client.HotspotsInRegion(ctx, "CA-AB", Fmt("csv"))
+		require.NoError(t, err)
+		assert.Len(t, hotspots, 3)
+		assert.Equal(t, "CA-AB-TW", hotspots[0].Subnational2Code)
+	})
+
+	t.Run("NearbyHotspots", func(t *testing.T) {
+		body = hotspotsCSV
+		hotspots, err := client.NearbyHotspots(ctx, Lat(50.2), Lng(-111.6), Fmt("csv"))
+		require.NoError(t, err)
+		assert.Len(t, hotspots, 3)
+		assert.Equal(t, 80, hotspots[0].NumSpeciesAllTime)
+		assert.Equal(t, "CA-AB-TW", hotspots[0].Subnational2Code)
+	})
+
+	t.Run("EbirdTaxonomy", func(t *testing.T) {
+		body = taxonomyCSV
+		taxonomy, err := client.EbirdTaxonomy(ctx, Fmt("csv"))
+		require.NoError(t, err)
+		assert.Len(t, taxonomy, 2)
+		assert.Equal(t, "ostric2", taxonomy[0].SpeciesCode)
+	})
+
+	t.Run("AdjacentRegions", func(t *testing.T) {
+		body = "US-NY-001,Albany\nUS-NY-093,Schenectady\n"
+		regions, err := client.AdjacentRegions(ctx, "US-NY-083", WithFormat("csv"))
+		require.NoError(t, err)
+		assert.Equal(t, []AdjacentRegion{{Code: "US-NY-001", Name: "Albany"}, {Code: "US-NY-093", Name: "Schenectady"}}, regions)
+	})
+
+	t.Run("Unsupported endpoint", func(t *testing.T) {
+		body = "whatever"
+		_, err := client.RecentObservationsInRegion(ctx, "US-NY", Fmt("csv"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CSV format is not supported")
+	})
+
+	t.Run("Raw streams are unchanged", func(t *testing.T) {
+		body = hotspotsCSV
+		rc, err := client.HotspotsInRegionCSV(ctx, "CA-AB")
+		require.NoError(t, err)
+		got, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		assert.Equal(t, hotspotsCSV, string(got))
+
+		rc, err = client.NearbyHotspotsCSV(ctx, Lat(50.2), Lng(-111.6))
+		require.NoError(t, err)
+		got, err = io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		assert.Equal(t, hotspotsCSV, string(got))
+
+		body = taxonomyCSV
+		rc, err = client.EbirdTaxonomyCSV(ctx, Fmt("json"))
+		require.NoError(t, err)
+		got, err = io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		assert.Equal(t, taxonomyCSV, string(got))
+	})
+}
+
+func TestJSONIsDefaultForHotspots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "json", r.URL.Query().Get("fmt"))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	_, err = client.HotspotsInRegion(context.Background(), "US-NY")
+	assert.NoError(t, err)
+	_, err = client.NearbyHotspots(context.Background(), Lat(42), Lng(-76))
+	assert.NoError(t, err)
+	_, err = client.EbirdTaxonomy(context.Background())
+	assert.NoError(t, err)
+}
@@ -1,6 +1,7 @@
 package ebird
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -77,6 +80,13 @@ type Client struct {
 	baseURL        *url.URL
 	httpClient     *http.Client
 	acceptLanguage string
+	retryPolicy    *RetryPolicy
+	rateLimiter    *RateLimiter
+	cache          Cache
+	cacheTTLs      map[CacheFamily]time.Duration
+	strictOptions  bool
+	parseTimes     bool
+	middleware     []Middleware
 }
 
 func WithAcceptLanguage(lang string) ClientOption {
@@ -104,6 +114,13 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 type Error struct {
 	Message string `json:"message"`
 	Status  int    `json:"status"`
+	// URL is the request URL with any credentials redacted.
+	URL string `json:"-"`
+	// Endpoint is the APIEndpoints field name of the failed call, such as
+	// "RecentObservationsInRegion", or "" for paths passed to Raw that match
+	// no endpoint.
+	Endpoint   string        `json:"-"`
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e Error) Error() string {
@@ -135,74 +152,332 @@ func NewClient(key string, opts ...ClientOption) (*Client, error) {
 	return c, nil
 }
 
-func (c *Client) get(ctx context.Context, endpoint string, params url.Values, result interface{}) error {
-	u, err := url.Parse(endpoint)
+func (c *Client) get(ctx context.Context, endpoint string, params RequestOptions, result interface{}) error {
+	return c.fetch(ctx, endpoint, params, func(body []byte) error {
+		return c.decode(body, params, result)
+	})
+}
+
+// Raw sends a GET request for endpoint, a path relative to the base URL such
+// as "data/obs/US-NY/recent", and returns the undecoded response body. It
+// goes through the same validation, cache, rate limiter and retries as the
+// typed methods. A 204 response returns a nil body.
+func (c *Client) Raw(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, error) {
+	var body []byte
+	err := c.fetch(ctx, strings.TrimPrefix(endpoint, "/"), processOptions(opts...), func(b []byte) error {
+		body = b
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("invalid endpoint URL: %w", err)
+		return nil, err
 	}
+	return body, nil
+}
 
-	u = c.baseURL.ResolveReference(u)
-	u.RawQuery = params.Encode()
+// fetch passes the response body for endpoint to accept, serving it from the
+// cache when possible. Bodies are only cached once accept has succeeded.
+// Cached bodies are copied on the way in and out, so that middleware and
+// callers of Raw may modify the bytes they are given.
+func (c *Client) fetch(ctx context.Context, endpoint string, params RequestOptions, accept func([]byte) error) error {
+	name, u, err := c.resolve(endpoint, params)
+	if err != nil {
+		return err
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	ttl := c.cacheTTL(endpoint)
+	useCache := c.cache != nil && ttl > 0 && params.cacheMode != cacheBypass
+
+	var cacheKey string
+	resp, err := c.roundTrip(ctx, name, u, params, func(ctx context.Context, req *Request) (*Response, error) {
+		if useCache {
+			cacheKey = c.cacheKey(req)
+			if params.cacheMode != cacheRefresh {
+				if body, ok := c.cache.Get(cacheKey); ok {
+					body = append([]byte(nil), body...)
+					return &Response{StatusCode: http.StatusOK, Body: body, BytesRead: int64(len(body)), CacheHit: true}, nil
+				}
+			}
+		}
+		return c.send(ctx, req, false)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	if c.acceptLanguage != "" {
-		req.Header.Set("Accept-Language", c.acceptLanguage)
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
 	}
 
-	req.Header.Set("X-eBirdApiToken", c.apikey)
-	req.Header.Set("Content-Type", "application/json")
+	if err := accept(resp.Body); err != nil {
+		return err
+	}
+
+	// cacheKey is empty if middleware answered without calling the handler.
+	if useCache && !resp.CacheHit && cacheKey != "" {
+		c.cache.Set(cacheKey, append([]byte(nil), resp.Body...), ttl)
+	}
+
+	return nil
+}
+
+func (c *Client) decode(body []byte, params RequestOptions, result interface{}) error {
+	if isCSV(params) {
+		if err := decodeCSV(body, result); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if c.parseTimes {
+		fillParsedTimes(result, params.regionCode)
+	}
+	return nil
+}
+
+// getRaw returns the unread response body. It bypasses the cache so that
+// large responses can be streamed.
+func (c *Client) getRaw(ctx context.Context, endpoint string, params RequestOptions) (io.ReadCloser, error) {
+	name, u, err := c.resolve(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.roundTrip(ctx, name, u, params, func(ctx context.Context, req *Request) (*Response, error) {
+		return c.send(ctx, req, true)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		if resp.stream != nil {
+			resp.stream.Close()
+		}
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNoContent {
-		return nil
+		if resp.stream != nil {
+			resp.stream.Close()
+		}
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+
+	if resp.stream != nil {
+		return resp.stream, nil
 	}
+	return io.NopCloser(bytes.NewReader(resp.Body)), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return c.decodeError(resp)
+// resolve validates params in strict mode and returns the endpoint name and
+// the absolute request URL.
+func (c *Client) resolve(endpoint string, params RequestOptions) (string, *url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid endpoint URL: %w", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	name, ok := EndpointName(endpoint)
+	if !ok {
+		name = ""
+	}
+	if c.strictOptions || params.strict {
+		if err := params.validate(name); err != nil {
+			return "", nil, err
+		}
 	}
 
-	return nil
+	u = c.baseURL.ResolveReference(u)
+	u.RawQuery = params.URLParams.Encode()
+	return name, u, nil
+}
+
+// do sends the request, retrying according to the client's RetryPolicy, and
+// returns the number of attempts made. On success the caller owns the
+// response body.
+func (c *Client) do(ctx context.Context, name string, u *url.URL, header http.Header) (*http.Response, int, error) {
+	req, err := c.newRequest(ctx, u, header)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	maxAttempts := c.retryPolicy.maxAttempts()
+
+	for attempt := 1; ; attempt++ {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, attempt - 1, fmt.Errorf("rate limiter: %w", err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+
+		var (
+			status     int
+			retryAfter time.Duration
+			retryable  bool
+		)
+		if err != nil {
+			retryable = isRetryableError(err)
+			err = fmt.Errorf("failed to send request: %w", err)
+		} else {
+			status = resp.StatusCode
+			if status == http.StatusOK || status == http.StatusNoContent {
+				c.retryPolicy.notify(RetryAttempt{Attempt: attempt, StatusCode: status})
+				return resp, attempt, nil
+			}
+			retryable = isRetryableStatus(status)
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			err = c.decodeError(resp, name, u, retryAfter)
+			resp.Body.Close()
+		}
+
+		retrying := retryable && attempt < maxAttempts && ctx.Err() == nil
+		var delay time.Duration
+		if retrying {
+			delay = c.retryPolicy.backoff(attempt, retryAfter)
+		}
+
+		c.retryPolicy.notify(RetryAttempt{
+			Attempt:    attempt,
+			StatusCode: status,
+			Err:        err,
+			Retrying:   retrying,
+			Delay:      delay,
+		})
+
+		if !retrying {
+			return nil, attempt, err
+		}
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return nil, attempt, err
+		}
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, u *url.URL, header http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+
+	if c.acceptLanguage != "" && req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+
+	req.Header.Set("X-eBirdApiToken", c.apikey)
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
 }
 
-func (c *Client) decodeError(resp *http.Response) error {
+func (c *Client) decodeError(resp *http.Response, name string, u *url.URL, retryAfter time.Duration) error {
+	apiErr := Error{
+		Status:     resp.StatusCode,
+		URL:        redactURL(u),
+		Endpoint:   name,
+		RetryAfter: retryAfter,
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("failed to read error response: %w", err)
 	}
 
-	if len(body) == 0 {
-		return fmt.Errorf("HTTP %d: %s (body empty)", resp.StatusCode, http.StatusText(resp.StatusCode))
+	var payload struct {
+		Error  json.RawMessage `json:"error"`
+		Errors []struct {
+			Title string `json:"title"`
+		} `json:"errors"`
 	}
-
-	var apiError struct {
-		Error Error `json:"error"`
+	if len(body) > 0 && json.Unmarshal(body, &payload) == nil {
+		var nested Error
+		var text string
+		switch {
+		case json.Unmarshal(payload.Error, &nested) == nil && nested.Message != "":
+			apiErr.Message = nested.Message
+		case json.Unmarshal(payload.Error, &text) == nil && text != "":
+			apiErr.Message = text
+		case len(payload.Errors) > 0 && payload.Errors[0].Title != "":
+			apiErr.Message = payload.Errors[0].Title
+		}
 	}
 
-	if err := json.Unmarshal(body, &apiError); err != nil {
-		return fmt.Errorf("failed to decode error response: %w", err)
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("unexpected HTTP %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		if len(body) == 0 {
+			apiErr.Message += " (body empty)"
+		}
 	}
 
-	if apiError.Error.Message == "" {
-		apiError.Error.Message = fmt.Sprintf("unexpected HTTP %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	return apiErr
+}
+
+// EndpointName returns the APIEndpoints field name whose template matches a
+// path relative to the base URL, such as "RecentObservationsInRegion" for
+// "data/obs/US-NY/recent". It reports false if no template matches.
+func EndpointName(path string) (string, bool) {
+	path = strings.Trim(path, "/")
+	name := endpointName(path)
+	return name, name != path
+}
+
+// endpointName returns the APIEndpoints field name whose template matches
+// endpoint, or endpoint itself if none does. When several templates match,
+// the one with the most literal segments wins, so "data/obs/geo/recent" is
+// RecentNearbyObservations rather than RecentObservationsInRegion.
+func endpointName(endpoint string) string {
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+
+	name, best := endpoint, -1
+	for _, t := range endpointTemplates {
+		if len(t.segments) != len(segments) {
+			continue
+		}
+
+		literals, ok := 0, true
+		for i, seg := range t.segments {
+			switch seg {
+			case "%s":
+				ok = segments[i] != ""
+			case "%d":
+				_, err := strconv.Atoi(segments[i])
+				ok = err == nil
+			default:
+				ok = seg == segments[i]
+				literals++
+			}
+			if !ok {
+				break
+			}
+		}
+
+		if ok && literals > best {
+			name, best = t.name, literals
+		}
 	}
+	return name
+}
 
-	apiError.Error.Status = resp.StatusCode
-	return apiError.Error
+type endpointTemplate struct {
+	name     string
+	segments []string
 }
 
+var endpointTemplates = func() []endpointTemplate {
+	v := reflect.ValueOf(APIEndpoints)
+	templates := make([]endpointTemplate, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		templates = append(templates, endpointTemplate{
+			name:     v.Type().Field(i).Name,
+			segments: strings.Split(v.Field(i).String(), "/"),
+		})
+	}
+	return templates
+}()
+
 func convertToJsonFormat(url string) string {
 	if !strings.Contains(url, "?") && !strings.Contains(url, "fmt=json") {
 		url += "?fmt=json"
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -59,7 +60,7 @@ func TestClientGet(t *testing.T) {
 		require.NoError(t, err)
 
 		var result map[string]string
-		err = client.get(context.Background(), "test", nil, &result)
+		err = client.get(context.Background(), "test", RequestOptions{}, &result)
 		assert.NoError(t, err)
 		assert.Equal(t, map[string]string{"key": "value"}, result)
 	})
@@ -80,7 +81,7 @@ func TestClientGet(t *testing.T) {
 		require.NoError(t, err)
 
 		var result map[string]string
-		err = client.get(context.Background(), "test", nil, &result)
+		err = client.get(context.Background(), "test", RequestOptions{}, &result)
 		assert.Error(t, err)
 		apiErr, ok := err.(Error)
 		assert.True(t, ok)
@@ -130,7 +131,23 @@ func TestClientWithAcceptLanguage(t *testing.T) {
 	client.baseURL, _ = client.baseURL.Parse(server.URL + "/")
 
 	var result map[string]string
-	err = client.get(context.Background(), "test", nil, &result)
+	err = client.get(context.Background(), "test", RequestOptions{}, &result)
 	assert.NoError(t, err)
 	assert.Equal(t, "Hola, mundo!", result["message"])
 }
+
+func TestClientRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/data/obs/US-NY/recent", r.URL.Path)
+		assert.Equal(t, "back=3&custom=x", r.URL.RawQuery)
+		w.Write([]byte(`[{"speciesCode":"amecro"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	body, err := client.Raw(context.Background(), "/data/obs/US-NY/recent", Back(3), Params(url.Values{"custom": {"x"}}))
+	require.NoError(t, err)
+	assert.Equal(t, `[{"speciesCode":"amecro"}]`, string(body))
+}
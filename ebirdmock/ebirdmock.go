@@ -0,0 +1,72 @@
+// Package ebirdmock provides a mock of ebird.API for unit tests.
+//
+// Set the Func fields for the calls a test expects and pass the mock wherever
+// an ebird.API or one of the smaller interfaces is accepted:
+//
+//	m := &ebirdmock.Client{
+//		RecentNotableObservationsInRegionFunc: func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+//			return []ebird.Observation{{SpeciesCode: "snoowl1"}}, nil
+//		},
+//	}
+//
+// The mock is generated from the interfaces in the ebird package by
+// internal/genapi.
+package ebirdmock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotMocked is returned by methods whose Func field is nil.
+var ErrNotMocked = errors.New("ebirdmock: method not mocked")
+
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
+
+// Call is a recorded call. Args holds the arguments after the context, with
+// variadic options as a slice.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (m *Client) Calls() []Call {
+	m.calls.mu.Lock()
+	defer m.calls.mu.Unlock()
+	return append([]Call(nil), m.calls.calls...)
+}
+
+// CallCount returns how many times method was called.
+func (m *Client) CallCount(method string) int {
+	m.calls.mu.Lock()
+	defer m.calls.mu.Unlock()
+	n := 0
+	for _, c := range m.calls.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset forgets the recorded calls.
+func (m *Client) Reset() {
+	m.calls.mu.Lock()
+	defer m.calls.mu.Unlock()
+	m.calls.calls = nil
+}
@@ -0,0 +1,56 @@
+package ebirdmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/siansiansu/go-ebird"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	m := &Client{
+		RecentNotableObservationsInRegionFunc: func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+			return []ebird.Observation{{SpeciesCode: "snoowl1", SubId: "S1"}}, nil
+		},
+	}
+
+	var api ebird.ObservationsAPI = m
+	obs, err := api.RecentNotableObservationsInRegion(context.Background(), "US-NY", ebird.Back(3))
+	require.NoError(t, err)
+	assert.Equal(t, "snoowl1", obs[0].SpeciesCode)
+
+	_, err = m.RegionInfo(context.Background(), "US-NY")
+	assert.ErrorIs(t, err, ErrNotMocked)
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "RecentNotableObservationsInRegion", calls[0].Method)
+	assert.Equal(t, "US-NY", calls[0].Args[0])
+	assert.Len(t, calls[0].Args[1], 1)
+	assert.Equal(t, 1, m.CallCount("RegionInfo"))
+
+	m.Reset()
+	assert.Empty(t, m.Calls())
+}
+
+func TestClientWithWatcher(t *testing.T) {
+	m := &Client{
+		RecentNotableObservationsInRegionFunc: func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+			return []ebird.Observation{{SpeciesCode: "snoowl1", SubId: "S1"}}, nil
+		},
+	}
+
+	var methods []string
+	api := ebird.Decorate(m, ebird.Intercept(func(ctx context.Context, method string, invoke func(context.Context) error) error {
+		methods = append(methods, method)
+		return invoke(ctx)
+	}))
+
+	w := ebird.NewWatcher(api, []ebird.WatchTarget{{RegionCode: "US-NY"}}, ebird.WithAlertOnFirstPoll())
+	alerts, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, []string{"RecentNotableObservationsInRegion"}, methods)
+}
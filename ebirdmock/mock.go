@@ -0,0 +1,331 @@
+// Code generated by internal/genapi; DO NOT EDIT.
+
+package ebirdmock
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+// Client is a mock ebird.API. Each method calls the matching Func field.
+// Methods whose Func is nil return ErrNotMocked.
+//
+// Calls are recorded whether or not they are mocked, and Client is safe for
+// concurrent use as long as the Func fields are set before the first call.
+type Client struct {
+	calls recorder
+
+	RecentObservationsInRegionFunc          func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	RecentNotableObservationsInRegionFunc   func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	RecentObservationsOfSpeciesInRegionFunc func(ctx context.Context, regionCode string, speciesCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	RecentNearbyObservationsFunc            func(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	RecentNearbyObservationsOfSpeciesFunc   func(ctx context.Context, speciesCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	NearestObservationsOfSpeciesFunc        func(ctx context.Context, speciesCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	RecentNearbyNotableObservationsFunc     func(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	RecentChecklistsFeedFunc                func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.RecentChecklistFeed, error)
+	HistoricObservationsOnDateFunc          func(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) ([]ebird.Observation, error)
+	Top100Func                              func(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) ([]ebird.Top100, error)
+	ChecklistFeedOnDateFunc                 func(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) ([]ebird.ChecklistFeedOnDate, error)
+	RegionalStatisticsOnDateFunc            func(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) (*ebird.RegionalStatisticsOnDate, error)
+	SpeciesListForRegionFunc                func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]string, error)
+	ViewChecklistFunc                       func(ctx context.Context, subId string, opts ...ebird.RequestOption) (*ebird.ViewChecklist, error)
+	HotspotsInRegionFunc                    func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.HotspotInRegion, error)
+	NearbyHotspotsFunc                      func(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.NearbyHotspot, error)
+	HotspotsInRegionCSVFunc                 func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) (io.ReadCloser, error)
+	NearbyHotspotsCSVFunc                   func(ctx context.Context, opts ...ebird.RequestOption) (io.ReadCloser, error)
+	HotspotInfoFunc                         func(ctx context.Context, locId string, opts ...ebird.RequestOption) (*ebird.HotspotInfo, error)
+	EbirdTaxonomyFunc                       func(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.EbirdTaxon, error)
+	EbirdTaxonomyCSVFunc                    func(ctx context.Context, opts ...ebird.RequestOption) (io.ReadCloser, error)
+	TaxonomicFormsFunc                      func(ctx context.Context, speciesCode string, opts ...ebird.RequestOption) ([]string, error)
+	TaxonomicGroupsFunc                     func(ctx context.Context, speciesGrouping string, opts ...ebird.RequestOption) ([]ebird.TaxonomicGroup, error)
+	TaxonomyVersionsFunc                    func(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.TaxonomyVersion, error)
+	TaxaLocaleCodesFunc                     func(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.TaxaLocaleCode, error)
+	RegionInfoFunc                          func(ctx context.Context, regionCode string, opts ...ebird.RequestOption) (*ebird.RegionInfo, error)
+	SubRegionListFunc                       func(ctx context.Context, regionType string, parentRegionCode string, opts ...ebird.RequestOption) ([]ebird.SubRegion, error)
+	AdjacentRegionsFunc                     func(ctx context.Context, regionCode string, opts ...ebird.AdjacentRegionsOption) ([]ebird.AdjacentRegion, error)
+}
+
+var _ ebird.API = (*Client)(nil)
+
+// RecentObservationsInRegion calls RecentObservationsInRegionFunc.
+func (m *Client) RecentObservationsInRegion(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("RecentObservationsInRegion", regionCode, opts)
+	if m.RecentObservationsInRegionFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("RecentObservationsInRegion")
+	}
+	return m.RecentObservationsInRegionFunc(ctx, regionCode, opts...)
+}
+
+// RecentNotableObservationsInRegion calls RecentNotableObservationsInRegionFunc.
+func (m *Client) RecentNotableObservationsInRegion(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("RecentNotableObservationsInRegion", regionCode, opts)
+	if m.RecentNotableObservationsInRegionFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("RecentNotableObservationsInRegion")
+	}
+	return m.RecentNotableObservationsInRegionFunc(ctx, regionCode, opts...)
+}
+
+// RecentObservationsOfSpeciesInRegion calls RecentObservationsOfSpeciesInRegionFunc.
+func (m *Client) RecentObservationsOfSpeciesInRegion(ctx context.Context, regionCode string, speciesCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("RecentObservationsOfSpeciesInRegion", regionCode, speciesCode, opts)
+	if m.RecentObservationsOfSpeciesInRegionFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("RecentObservationsOfSpeciesInRegion")
+	}
+	return m.RecentObservationsOfSpeciesInRegionFunc(ctx, regionCode, speciesCode, opts...)
+}
+
+// RecentNearbyObservations calls RecentNearbyObservationsFunc.
+func (m *Client) RecentNearbyObservations(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("RecentNearbyObservations", opts)
+	if m.RecentNearbyObservationsFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("RecentNearbyObservations")
+	}
+	return m.RecentNearbyObservationsFunc(ctx, opts...)
+}
+
+// RecentNearbyObservationsOfSpecies calls RecentNearbyObservationsOfSpeciesFunc.
+func (m *Client) RecentNearbyObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("RecentNearbyObservationsOfSpecies", speciesCode, opts)
+	if m.RecentNearbyObservationsOfSpeciesFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("RecentNearbyObservationsOfSpecies")
+	}
+	return m.RecentNearbyObservationsOfSpeciesFunc(ctx, speciesCode, opts...)
+}
+
+// NearestObservationsOfSpecies calls NearestObservationsOfSpeciesFunc.
+func (m *Client) NearestObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("NearestObservationsOfSpecies", speciesCode, opts)
+	if m.NearestObservationsOfSpeciesFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("NearestObservationsOfSpecies")
+	}
+	return m.NearestObservationsOfSpeciesFunc(ctx, speciesCode, opts...)
+}
+
+// RecentNearbyNotableObservations calls RecentNearbyNotableObservationsFunc.
+func (m *Client) RecentNearbyNotableObservations(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("RecentNearbyNotableObservations", opts)
+	if m.RecentNearbyNotableObservationsFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("RecentNearbyNotableObservations")
+	}
+	return m.RecentNearbyNotableObservationsFunc(ctx, opts...)
+}
+
+// RecentChecklistsFeed calls RecentChecklistsFeedFunc.
+func (m *Client) RecentChecklistsFeed(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.RecentChecklistFeed, error) {
+	m.calls.record("RecentChecklistsFeed", regionCode, opts)
+	if m.RecentChecklistsFeedFunc == nil {
+		var zero []ebird.RecentChecklistFeed
+		return zero, notMocked("RecentChecklistsFeed")
+	}
+	return m.RecentChecklistsFeedFunc(ctx, regionCode, opts...)
+}
+
+// HistoricObservationsOnDate calls HistoricObservationsOnDateFunc.
+func (m *Client) HistoricObservationsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) ([]ebird.Observation, error) {
+	m.calls.record("HistoricObservationsOnDate", regionCode, date, opts)
+	if m.HistoricObservationsOnDateFunc == nil {
+		var zero []ebird.Observation
+		return zero, notMocked("HistoricObservationsOnDate")
+	}
+	return m.HistoricObservationsOnDateFunc(ctx, regionCode, date, opts...)
+}
+
+// Top100 calls Top100Func.
+func (m *Client) Top100(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) ([]ebird.Top100, error) {
+	m.calls.record("Top100", regionCode, date, opts)
+	if m.Top100Func == nil {
+		var zero []ebird.Top100
+		return zero, notMocked("Top100")
+	}
+	return m.Top100Func(ctx, regionCode, date, opts...)
+}
+
+// ChecklistFeedOnDate calls ChecklistFeedOnDateFunc.
+func (m *Client) ChecklistFeedOnDate(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) ([]ebird.ChecklistFeedOnDate, error) {
+	m.calls.record("ChecklistFeedOnDate", regionCode, date, opts)
+	if m.ChecklistFeedOnDateFunc == nil {
+		var zero []ebird.ChecklistFeedOnDate
+		return zero, notMocked("ChecklistFeedOnDate")
+	}
+	return m.ChecklistFeedOnDateFunc(ctx, regionCode, date, opts...)
+}
+
+// RegionalStatisticsOnDate calls RegionalStatisticsOnDateFunc.
+func (m *Client) RegionalStatisticsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...ebird.RequestOption) (*ebird.RegionalStatisticsOnDate, error) {
+	m.calls.record("RegionalStatisticsOnDate", regionCode, date, opts)
+	if m.RegionalStatisticsOnDateFunc == nil {
+		var zero *ebird.RegionalStatisticsOnDate
+		return zero, notMocked("RegionalStatisticsOnDate")
+	}
+	return m.RegionalStatisticsOnDateFunc(ctx, regionCode, date, opts...)
+}
+
+// SpeciesListForRegion calls SpeciesListForRegionFunc.
+func (m *Client) SpeciesListForRegion(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]string, error) {
+	m.calls.record("SpeciesListForRegion", regionCode, opts)
+	if m.SpeciesListForRegionFunc == nil {
+		var zero []string
+		return zero, notMocked("SpeciesListForRegion")
+	}
+	return m.SpeciesListForRegionFunc(ctx, regionCode, opts...)
+}
+
+// ViewChecklist calls ViewChecklistFunc.
+func (m *Client) ViewChecklist(ctx context.Context, subId string, opts ...ebird.RequestOption) (*ebird.ViewChecklist, error) {
+	m.calls.record("ViewChecklist", subId, opts)
+	if m.ViewChecklistFunc == nil {
+		var zero *ebird.ViewChecklist
+		return zero, notMocked("ViewChecklist")
+	}
+	return m.ViewChecklistFunc(ctx, subId, opts...)
+}
+
+// HotspotsInRegion calls HotspotsInRegionFunc.
+func (m *Client) HotspotsInRegion(ctx context.Context, regionCode string, opts ...ebird.RequestOption) ([]ebird.HotspotInRegion, error) {
+	m.calls.record("HotspotsInRegion", regionCode, opts)
+	if m.HotspotsInRegionFunc == nil {
+		var zero []ebird.HotspotInRegion
+		return zero, notMocked("HotspotsInRegion")
+	}
+	return m.HotspotsInRegionFunc(ctx, regionCode, opts...)
+}
+
+// NearbyHotspots calls NearbyHotspotsFunc.
+func (m *Client) NearbyHotspots(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.NearbyHotspot, error) {
+	m.calls.record("NearbyHotspots", opts)
+	if m.NearbyHotspotsFunc == nil {
+		var zero []ebird.NearbyHotspot
+		return zero, notMocked("NearbyHotspots")
+	}
+	return m.NearbyHotspotsFunc(ctx, opts...)
+}
+
+// HotspotsInRegionCSV calls HotspotsInRegionCSVFunc.
+func (m *Client) HotspotsInRegionCSV(ctx context.Context, regionCode string, opts ...ebird.RequestOption) (io.ReadCloser, error) {
+	m.calls.record("HotspotsInRegionCSV", regionCode, opts)
+	if m.HotspotsInRegionCSVFunc == nil {
+		var zero io.ReadCloser
+		return zero, notMocked("HotspotsInRegionCSV")
+	}
+	return m.HotspotsInRegionCSVFunc(ctx, regionCode, opts...)
+}
+
+// NearbyHotspotsCSV calls NearbyHotspotsCSVFunc.
+func (m *Client) NearbyHotspotsCSV(ctx context.Context, opts ...ebird.RequestOption) (io.ReadCloser, error) {
+	m.calls.record("NearbyHotspotsCSV", opts)
+	if m.NearbyHotspotsCSVFunc == nil {
+		var zero io.ReadCloser
+		return zero, notMocked("NearbyHotspotsCSV")
+	}
+	return m.NearbyHotspotsCSVFunc(ctx, opts...)
+}
+
+// HotspotInfo calls HotspotInfoFunc.
+func (m *Client) HotspotInfo(ctx context.Context, locId string, opts ...ebird.RequestOption) (*ebird.HotspotInfo, error) {
+	m.calls.record("HotspotInfo", locId, opts)
+	if m.HotspotInfoFunc == nil {
+		var zero *ebird.HotspotInfo
+		return zero, notMocked("HotspotInfo")
+	}
+	return m.HotspotInfoFunc(ctx, locId, opts...)
+}
+
+// EbirdTaxonomy calls EbirdTaxonomyFunc.
+func (m *Client) EbirdTaxonomy(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.EbirdTaxon, error) {
+	m.calls.record("EbirdTaxonomy", opts)
+	if m.EbirdTaxonomyFunc == nil {
+		var zero []ebird.EbirdTaxon
+		return zero, notMocked("EbirdTaxonomy")
+	}
+	return m.EbirdTaxonomyFunc(ctx, opts...)
+}
+
+// EbirdTaxonomyCSV calls EbirdTaxonomyCSVFunc.
+func (m *Client) EbirdTaxonomyCSV(ctx context.Context, opts ...ebird.RequestOption) (io.ReadCloser, error) {
+	m.calls.record("EbirdTaxonomyCSV", opts)
+	if m.EbirdTaxonomyCSVFunc == nil {
+		var zero io.ReadCloser
+		return zero, notMocked("EbirdTaxonomyCSV")
+	}
+	return m.EbirdTaxonomyCSVFunc(ctx, opts...)
+}
+
+// TaxonomicForms calls TaxonomicFormsFunc.
+func (m *Client) TaxonomicForms(ctx context.Context, speciesCode string, opts ...ebird.RequestOption) ([]string, error) {
+	m.calls.record("TaxonomicForms", speciesCode, opts)
+	if m.TaxonomicFormsFunc == nil {
+		var zero []string
+		return zero, notMocked("TaxonomicForms")
+	}
+	return m.TaxonomicFormsFunc(ctx, speciesCode, opts...)
+}
+
+// TaxonomicGroups calls TaxonomicGroupsFunc.
+func (m *Client) TaxonomicGroups(ctx context.Context, speciesGrouping string, opts ...ebird.RequestOption) ([]ebird.TaxonomicGroup, error) {
+	m.calls.record("TaxonomicGroups", speciesGrouping, opts)
+	if m.TaxonomicGroupsFunc == nil {
+		var zero []ebird.TaxonomicGroup
+		return zero, notMocked("TaxonomicGroups")
+	}
+	return m.TaxonomicGroupsFunc(ctx, speciesGrouping, opts...)
+}
+
+// TaxonomyVersions calls TaxonomyVersionsFunc.
+func (m *Client) TaxonomyVersions(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.TaxonomyVersion, error) {
+	m.calls.record("TaxonomyVersions", opts)
+	if m.TaxonomyVersionsFunc == nil {
+		var zero []ebird.TaxonomyVersion
+		return zero, notMocked("TaxonomyVersions")
+	}
+	return m.TaxonomyVersionsFunc(ctx, opts...)
+}
+
+// TaxaLocaleCodes calls TaxaLocaleCodesFunc.
+func (m *Client) TaxaLocaleCodes(ctx context.Context, opts ...ebird.RequestOption) ([]ebird.TaxaLocaleCode, error) {
+	m.calls.record("TaxaLocaleCodes", opts)
+	if m.TaxaLocaleCodesFunc == nil {
+		var zero []ebird.TaxaLocaleCode
+		return zero, notMocked("TaxaLocaleCodes")
+	}
+	return m.TaxaLocaleCodesFunc(ctx, opts...)
+}
+
+// RegionInfo calls RegionInfoFunc.
+func (m *Client) RegionInfo(ctx context.Context, regionCode string, opts ...ebird.RequestOption) (*ebird.RegionInfo, error) {
+	m.calls.record("RegionInfo", regionCode, opts)
+	if m.RegionInfoFunc == nil {
+		var zero *ebird.RegionInfo
+		return zero, notMocked("RegionInfo")
+	}
+	return m.RegionInfoFunc(ctx, regionCode, opts...)
+}
+
+// SubRegionList calls SubRegionListFunc.
+func (m *Client) SubRegionList(ctx context.Context, regionType string, parentRegionCode string, opts ...ebird.RequestOption) ([]ebird.SubRegion, error) {
+	m.calls.record("SubRegionList", regionType, parentRegionCode, opts)
+	if m.SubRegionListFunc == nil {
+		var zero []ebird.SubRegion
+		return zero, notMocked("SubRegionList")
+	}
+	return m.SubRegionListFunc(ctx, regionType, parentRegionCode, opts...)
+}
+
+// AdjacentRegions calls AdjacentRegionsFunc.
+func (m *Client) AdjacentRegions(ctx context.Context, regionCode string, opts ...ebird.AdjacentRegionsOption) ([]ebird.AdjacentRegion, error) {
+	m.calls.record("AdjacentRegions", regionCode, opts)
+	if m.AdjacentRegionsFunc == nil {
+		var zero []ebird.AdjacentRegion
+		return zero, notMocked("AdjacentRegions")
+	}
+	return m.AdjacentRegionsFunc(ctx, regionCode, opts...)
+}
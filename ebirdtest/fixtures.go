@@ -0,0 +1,89 @@
+package ebirdtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+// Fixtures is the data a Server answers from. Product endpoints such as
+// Top100, RegionalStatisticsOnDate and SpeciesListForRegion are derived from
+// the observations and checklists, so they stay consistent with each other.
+//
+// Fixtures can be built as Go values or loaded from a JSON file with the
+// same field names.
+type Fixtures struct {
+	Observations     []Observation                     `json:"observations,omitempty"`
+	Checklists       []Checklist                       `json:"checklists,omitempty"`
+	Hotspots         []ebird.HotspotInRegion           `json:"hotspots,omitempty"`
+	Regions          []Region                          `json:"regions,omitempty"`
+	Taxonomy         []ebird.EbirdTaxon                `json:"taxonomy,omitempty"`
+	TaxonomicForms   map[string][]string               `json:"taxonomicForms,omitempty"`
+	TaxonomicGroups  map[string][]ebird.TaxonomicGroup `json:"taxonomicGroups,omitempty"`
+	TaxonomyVersions []ebird.TaxonomyVersion           `json:"taxonomyVersions,omitempty"`
+	TaxaLocaleCodes  []ebird.TaxaLocaleCode            `json:"taxaLocaleCodes,omitempty"`
+}
+
+// Observation is an observation together with where it was made.
+type Observation struct {
+	ebird.Observation
+	// RegionCode is the most specific region of the observation, such as
+	// "US-NY-109". It matches requests for any enclosing region. If empty,
+	// the region of the hotspot with the same LocId is used.
+	RegionCode string `json:"regionCode,omitempty"`
+	// Notable marks the observation as a rarity for the notable endpoints.
+	Notable bool `json:"notable,omitempty"`
+	// Hotspot marks the location as a hotspot. Observations at a LocId
+	// listed in Fixtures.Hotspots are always at a hotspot.
+	Hotspot bool `json:"hotspot,omitempty"`
+}
+
+// Checklist is a full checklist together with its region. Feeds are built
+// from these.
+type Checklist struct {
+	ebird.ViewChecklist
+	RegionCode string `json:"regionCode,omitempty"`
+	LocName    string `json:"locName,omitempty"`
+}
+
+// Region is a country, subnational1 or subnational2 region.
+type Region struct {
+	Code   string       `json:"code"`
+	Name   string       `json:"name"`
+	Bounds ebird.Bounds `json:"bounds"`
+	// Adjacent lists the codes of neighbouring regions. Adjacency is
+	// symmetric, so each pair only needs to be listed once.
+	Adjacent []string `json:"adjacent,omitempty"`
+}
+
+// ReadFixtures decodes fixtures from JSON.
+func ReadFixtures(r io.Reader) (*Fixtures, error) {
+	var f Fixtures
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixtures: %w", err)
+	}
+	return &f, nil
+}
+
+// LoadFixtures reads fixtures from a JSON file.
+func LoadFixtures(path string) (*Fixtures, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixtures: %w", err)
+	}
+	defer f.Close()
+	return ReadFixtures(f)
+}
+
+// MustLoadFixtures is like LoadFixtures but panics on error. It is meant for
+// package-level test variables.
+func MustLoadFixtures(path string) *Fixtures {
+	f, err := LoadFixtures(path)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
@@ -0,0 +1,977 @@
+package ebirdtest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+const (
+	defaultBack       = 14
+	defaultDist       = 25
+	isoDateTimeLayout = "2006-01-02 15:04"
+)
+
+// request is the parsed form of a request to a known endpoint.
+type request struct {
+	fixtures *Fixtures
+	args     []string
+	query    url.Values
+	now      time.Time
+}
+
+var handlers = map[string]func(*request) response{
+	"RecentObservationsInRegion": func(r *request) response {
+		return r.observations(obsQuery{region: r.args[0], back: true, latest: true})
+	},
+	"RecentNotableObservationsInRegion": func(r *request) response {
+		return r.observations(obsQuery{region: r.args[0], back: true, notable: true})
+	},
+	"RecentObservationsOfSpeciesInRegion": func(r *request) response {
+		return r.observations(obsQuery{region: r.args[0], species: r.args[1], back: true})
+	},
+	"RecentNearbyObservations": func(r *request) response {
+		return r.observations(obsQuery{geo: true, back: true, latest: true})
+	},
+	"RecentNearbyObservationsOfSpecies": func(r *request) response {
+		return r.observations(obsQuery{geo: true, species: r.args[0], back: true})
+	},
+	"NearestObservationsOfSpecies": func(r *request) response {
+		return r.observations(obsQuery{geo: true, species: r.args[0], back: true, nearest: true})
+	},
+	"RecentNearbyNotableObservations": func(r *request) response {
+		return r.observations(obsQuery{geo: true, back: true, notable: true})
+	},
+	"HistoricObservationsOnDate": func(r *request) response {
+		date, err := r.date(1)
+		if err != nil {
+			return badRequest(err.Error())
+		}
+		return r.observations(obsQuery{region: r.args[0], date: date, latest: true})
+	},
+	"RecentChecklistsFeed":     handleRecentChecklistsFeed,
+	"ChecklistFeedOnDate":      handleChecklistFeedOnDate,
+	"RegionalStatisticsOnDate": handleRegionalStatistics,
+	"SpeciesListForRegion":     handleSpeciesList,
+	"Top100":                   handleTop100,
+	"ViewChecklist": func(r *request) response {
+		for _, c := range r.fixtures.Checklists {
+			if c.SubId == r.args[0] {
+				return response{json: c.ViewChecklist}
+			}
+		}
+		return notFound("Checklist " + r.args[0] + " not found")
+	},
+	"AdjacentRegions":  handleAdjacentRegions,
+	"HotspotInfo":      handleHotspotInfo,
+	"HotspotsInRegion": handleHotspotsInRegion,
+	"NearbyHotspots":   handleNearbyHotspots,
+	"EbirdTaxonomy":    handleTaxonomy,
+	"TaxaLocaleCodes": func(r *request) response {
+		return response{json: nonNil(r.fixtures.TaxaLocaleCodes)}
+	},
+	"TaxonomicForms": func(r *request) response {
+		if forms, ok := r.fixtures.TaxonomicForms[r.args[0]]; ok {
+			return response{json: forms}
+		}
+		return response{json: []string{r.args[0]}}
+	},
+	"TaxonomicGroups": func(r *request) response {
+		grouping := r.args[0]
+		if grouping != "ebird" && grouping != "merlin" {
+			return badRequest(fmt.Sprintf("Unknown species grouping %q", grouping))
+		}
+		return response{json: nonNil(r.fixtures.TaxonomicGroups[grouping])}
+	},
+	"TaxonomyVersions": func(r *request) response {
+		return response{json: nonNil(r.fixtures.TaxonomyVersions)}
+	},
+	"RegionInfo":    handleRegionInfo,
+	"SubRegionInfo": handleSubRegionList,
+}
+
+func badRequest(message string) response {
+	return response{status: http.StatusBadRequest, message: message}
+}
+
+func notFound(message string) response {
+	return response{status: http.StatusNotFound, message: message}
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// filters holds the query parameters shared by several endpoints.
+type filters struct {
+	back       int
+	maxResults int
+	hasGeo     bool
+	lat, lng   float64
+	dist       float64
+	hotspot    bool
+	r          []string
+	cat        []string
+	species    []string
+}
+
+func (r *request) filters() (filters, error) {
+	f := filters{back: defaultBack, dist: -1}
+	var err error
+	if v := r.query.Get("back"); v != "" {
+		if f.back, err = strconv.Atoi(v); err != nil || f.back < 1 || f.back > 30 {
+			return f, fmt.Errorf("back must be between 1 and 30")
+		}
+	}
+	if v := r.query.Get("maxResults"); v != "" {
+		if f.maxResults, err = strconv.Atoi(v); err != nil || f.maxResults < 1 {
+			return f, fmt.Errorf("maxResults must be a positive number")
+		}
+	}
+	if v := r.query.Get("dist"); v != "" {
+		if f.dist, err = strconv.ParseFloat(v, 64); err != nil || f.dist < 0 {
+			return f, fmt.Errorf("dist must be a non-negative number")
+		}
+	}
+	lat, lng := r.query.Get("lat"), r.query.Get("lng")
+	if lat != "" || lng != "" {
+		if f.lat, err = strconv.ParseFloat(lat, 64); err != nil || math.Abs(f.lat) > 90 {
+			return f, fmt.Errorf("lat must be between -90 and 90")
+		}
+		if f.lng, err = strconv.ParseFloat(lng, 64); err != nil || math.Abs(f.lng) > 180 {
+			return f, fmt.Errorf("lng must be between -180 and 180")
+		}
+		f.hasGeo = true
+	}
+	f.hotspot = r.query.Get("hotspot") == "true"
+	f.r = splitParam(r.query.Get("r"))
+	f.cat = splitParam(r.query.Get("cat"))
+	f.species = splitParam(r.query.Get("species"))
+	return f, nil
+}
+
+func splitParam(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// date parses the year, month and day path arguments starting at index i.
+func (r *request) date(i int) (time.Time, error) {
+	var parts [3]int
+	for j := range parts {
+		n, err := strconv.Atoi(r.args[i+j])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date")
+		}
+		parts[j] = n
+	}
+	date := time.Date(parts[0], time.Month(parts[1]), parts[2], 0, 0, 0, 0, time.UTC)
+	if date.Year() != parts[0] || int(date.Month()) != parts[1] || date.Day() != parts[2] {
+		return time.Time{}, fmt.Errorf("invalid date")
+	}
+	return date, nil
+}
+
+func checkRegion(code string) error {
+	if _, err := ebird.ParseRegionCode(code); err != nil {
+		return fmt.Errorf("Invalid region code %q", code)
+	}
+	return nil
+}
+
+// inRegion reports whether an item in region, at locId, matches the
+// requested region or location code.
+func inRegion(want, region, locId string) bool {
+	if strings.EqualFold(want, locId) {
+		return true
+	}
+	if region == "" {
+		return false
+	}
+	return ebird.RegionCode(strings.ToUpper(want)).Contains(ebird.RegionCode(region))
+}
+
+func parseObsDate(value string) (time.Time, bool) {
+	t, _, err := ebird.ParseDateTime(value, time.UTC)
+	return t, err == nil
+}
+
+// withinBack reports whether t falls on one of the last back days.
+func withinBack(t, now time.Time, back int) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return !t.Before(today.AddDate(0, 0, -(back-1))) && !t.After(now)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// distanceKm returns the great-circle distance between two points.
+func distanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+type obsQuery struct {
+	region  string
+	species string
+	geo     bool
+	back    bool
+	date    time.Time
+	notable bool
+	latest  bool
+	nearest bool
+}
+
+// observations answers the observation endpoints. Results are newest first,
+// or nearest first for NearestObservationsOfSpecies. With latest set only
+// the most recent observation of each species is kept, as the API does.
+func (r *request) observations(q obsQuery) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	if q.region != "" {
+		if err := checkRegion(q.region); err != nil {
+			return badRequest(err.Error())
+		}
+	}
+	if q.geo && !f.hasGeo {
+		return badRequest("lat and lng are required")
+	}
+	if q.geo && f.dist < 0 && !q.nearest {
+		f.dist = defaultDist
+	}
+
+	hotspots := make(map[string]ebird.HotspotInRegion, len(r.fixtures.Hotspots))
+	for _, h := range r.fixtures.Hotspots {
+		hotspots[h.LocId] = h
+	}
+	categories := r.categories()
+
+	type match struct {
+		obs  ebird.Observation
+		t    time.Time
+		dist float64
+	}
+	var matches []match
+	for _, o := range r.fixtures.Observations {
+		region, isHotspot := o.RegionCode, o.Hotspot
+		if h, ok := hotspots[o.LocId]; ok {
+			isHotspot = true
+			if region == "" {
+				region = hotspotRegion(h)
+			}
+		}
+
+		if q.notable && !o.Notable {
+			continue
+		}
+		if q.species != "" && o.SpeciesCode != q.species {
+			continue
+		}
+		if q.region != "" && !inRegion(q.region, region, o.LocId) {
+			continue
+		}
+		if len(f.r) > 0 && !anyRegion(f.r, region, o.LocId) {
+			continue
+		}
+		if f.hotspot && !isHotspot {
+			continue
+		}
+		if len(f.cat) > 0 && !contains(f.cat, categoryOf(categories, o.SpeciesCode)) {
+			continue
+		}
+
+		t, ok := parseObsDate(o.ObsDt)
+		if q.back && ok && !withinBack(t, r.now, f.back) {
+			continue
+		}
+		if !q.date.IsZero() && (!ok || !sameDay(t, q.date)) {
+			continue
+		}
+
+		m := match{obs: o.Observation, t: t}
+		if f.hasGeo {
+			m.dist = distanceKm(f.lat, f.lng, o.Lat, o.Lng)
+			if f.dist >= 0 && m.dist > f.dist {
+				continue
+			}
+		}
+		matches = append(matches, m)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if q.nearest {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].t.After(matches[j].t)
+	})
+
+	result := make([]ebird.Observation, 0, len(matches))
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if q.latest {
+			if seen[m.obs.SpeciesCode] {
+				continue
+			}
+			seen[m.obs.SpeciesCode] = true
+		}
+		result = append(result, m.obs)
+	}
+	return response{json: limit(result, f.maxResults)}
+}
+
+func limit[T any](s []T, max int) []T {
+	if max > 0 && len(s) > max {
+		return s[:max]
+	}
+	return s
+}
+
+func anyRegion(wants []string, region, locId string) bool {
+	for _, want := range wants {
+		if inRegion(want, region, locId) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+// categories maps species codes to their taxonomy category.
+func (r *request) categories() map[string]string {
+	m := make(map[string]string, len(r.fixtures.Taxonomy))
+	for _, t := range r.fixtures.Taxonomy {
+		m[t.SpeciesCode] = t.Category
+	}
+	return m
+}
+
+// categoryOf returns the category of a species, assuming "species" for
+// codes missing from the fixture taxonomy.
+func categoryOf(categories map[string]string, speciesCode string) string {
+	if c, ok := categories[speciesCode]; ok && c != "" {
+		return c
+	}
+	return "species"
+}
+
+func hotspotRegion(h ebird.HotspotInRegion) string {
+	switch {
+	case h.Subnational2Code != "":
+		return h.Subnational2Code
+	case h.Subnational1Code != "":
+		return h.Subnational1Code
+	}
+	return h.CountryCode
+}
+
+// checklistsIn returns the checklists in region, newest first, optionally
+// on one date.
+func (r *request) checklistsIn(region string, date time.Time) []Checklist {
+	type dated struct {
+		c Checklist
+		t time.Time
+	}
+	var matches []dated
+	for _, c := range r.fixtures.Checklists {
+		if !inRegion(region, c.RegionCode, c.LocId) {
+			continue
+		}
+		t, ok := parseObsDate(c.ObsDt)
+		if !date.IsZero() && (!ok || !sameDay(t, date)) {
+			continue
+		}
+		matches = append(matches, dated{c, t})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].t.After(matches[j].t)
+	})
+
+	out := make([]Checklist, len(matches))
+	for i, m := range matches {
+		out[i] = m.c
+	}
+	return out
+}
+
+func checklistSpecies(c Checklist) int {
+	if c.NumSpecies > 0 {
+		return c.NumSpecies
+	}
+	species := make(map[string]bool)
+	for _, o := range c.Obs {
+		species[o.SpeciesCode] = true
+	}
+	return len(species)
+}
+
+// feedFields returns the obsDt, obsTime and isoObsDate of a feed entry.
+func feedFields(c Checklist) (string, string, string) {
+	t, hasTime, err := ebird.ParseDateTime(c.ObsDt, time.UTC)
+	if err != nil {
+		return c.ObsDt, "", c.ObsDt
+	}
+	if !hasTime {
+		return t.Format("2 Jan 2006"), "", t.Format("2006-01-02")
+	}
+	return t.Format("2 Jan 2006"), t.Format("15:04"), t.Format(isoDateTimeLayout)
+}
+
+func (r *request) hotspotInfo(locId, name string, region string) ebird.HotspotInfo {
+	info := ebird.HotspotInfo{LocId: locId, LocID: locId, Name: name, LocName: name}
+	for _, h := range r.fixtures.Hotspots {
+		if h.LocId == locId {
+			info.IsHotspot = true
+			info.Lat, info.Lng = h.Lat, h.Lng
+			info.Latitude, info.Longitude = h.Lat, h.Lng
+			if info.Name == "" {
+				info.Name, info.LocName = h.LocName, h.LocName
+			}
+			if region == "" {
+				region = hotspotRegion(h)
+			}
+		}
+	}
+
+	code := ebird.RegionCode(region)
+	info.CountryCode = code.Country().String()
+	info.CountryName = r.regionName(info.CountryCode)
+	info.Subnational1Code = code.Subnational1().String()
+	info.Subnational1Name = r.regionName(info.Subnational1Code)
+	if code.Type() == ebird.RegionTypeSubnational2 {
+		info.Subnational2Code = region
+		info.Subnational2Name = r.regionName(region)
+	}
+
+	var names []string
+	for _, n := range []string{info.Name, info.Subnational2Name, info.Subnational1Name, info.CountryName} {
+		if n != "" {
+			names = append(names, n)
+		}
+	}
+	info.HierarchicalName = strings.Join(names, ", ")
+	return info
+}
+
+func handleRecentChecklistsFeed(r *request) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	if err := checkRegion(r.args[0]); err != nil {
+		return badRequest(err.Error())
+	}
+	if f.maxResults == 0 {
+		f.maxResults = 10
+	}
+
+	feed := []ebird.RecentChecklistFeed{}
+	for _, c := range r.checklistsIn(r.args[0], time.Time{}) {
+		obsDt, obsTime, iso := feedFields(c)
+		info := r.hotspotInfo(c.LocId, c.LocName, c.RegionCode)
+		feed = append(feed, ebird.RecentChecklistFeed{
+			LocId:           c.LocId,
+			SubId:           c.SubId,
+			SubID:           c.SubId,
+			UserDisplayName: c.UserDisplayName,
+			NumSpecies:      checklistSpecies(c),
+			ObsDt:           obsDt,
+			ObsTime:         obsTime,
+			IsoObsDate:      iso,
+			Loc: ebird.Location{
+				LocId:            info.LocId,
+				LocID:            info.LocId,
+				Name:             info.Name,
+				LocName:          info.Name,
+				Latitude:         info.Latitude,
+				Longitude:        info.Longitude,
+				Lat:              info.Lat,
+				Lng:              info.Lng,
+				CountryCode:      info.CountryCode,
+				CountryName:      info.CountryName,
+				Subnational1Code: info.Subnational1Code,
+				Subnational1Name: info.Subnational1Name,
+				Subnational2Code: info.Subnational2Code,
+				Subnational2Name: info.Subnational2Name,
+				IsHotspot:        info.IsHotspot,
+				HierarchicalName: info.HierarchicalName,
+			},
+		})
+	}
+	return response{json: limit(feed, f.maxResults)}
+}
+
+func handleChecklistFeedOnDate(r *request) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	if err := checkRegion(r.args[0]); err != nil {
+		return badRequest(err.Error())
+	}
+	date, err := r.date(1)
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	if f.maxResults == 0 {
+		f.maxResults = 10
+	}
+
+	checklists := r.checklistsIn(r.args[0], date)
+	switch r.query.Get("sortKey") {
+	case "", "obs_dt":
+	case "creation_dt":
+		sort.SliceStable(checklists, func(i, j int) bool {
+			return checklists[i].CreationDt > checklists[j].CreationDt
+		})
+	default:
+		return badRequest("sortKey must be obs_dt or creation_dt")
+	}
+
+	feed := []ebird.ChecklistFeedOnDate{}
+	for _, c := range checklists {
+		obsDt, obsTime, iso := feedFields(c)
+		feed = append(feed, ebird.ChecklistFeedOnDate{
+			LocId:           c.LocId,
+			SubId:           c.SubId,
+			SubID:           c.SubId,
+			UserDisplayName: c.UserDisplayName,
+			NumSpecies:      checklistSpecies(c),
+			ObsDt:           obsDt,
+			ObsTime:         obsTime,
+			IsoObsDate:      iso,
+			Loc:             r.hotspotInfo(c.LocId, c.LocName, c.RegionCode),
+		})
+	}
+	return response{json: limit(feed, f.maxResults)}
+}
+
+func handleRegionalStatistics(r *request) response {
+	if err := checkRegion(r.args[0]); err != nil {
+		return badRequest(err.Error())
+	}
+	date, err := r.date(1)
+	if err != nil {
+		return badRequest(err.Error())
+	}
+
+	checklists := r.checklistsIn(r.args[0], date)
+	users := make(map[string]bool)
+	species := make(map[string]bool)
+	for _, c := range checklists {
+		users[c.UserDisplayName] = true
+		for _, o := range c.Obs {
+			species[o.SpeciesCode] = true
+		}
+	}
+	return response{json: ebird.RegionalStatisticsOnDate{
+		NumChecklists:   len(checklists),
+		NumContributors: len(users),
+		NumSpecies:      len(species),
+	}}
+}
+
+func handleTop100(r *request) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	if err := checkRegion(r.args[0]); err != nil {
+		return badRequest(err.Error())
+	}
+	date, err := r.date(1)
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	rankedBy := r.query.Get("rankedBy")
+	if rankedBy == "" {
+		rankedBy = "spp"
+	}
+	if rankedBy != "spp" && rankedBy != "cl" {
+		return badRequest("rankedBy must be spp or cl")
+	}
+	if f.maxResults == 0 || f.maxResults > 100 {
+		f.maxResults = 100
+	}
+
+	type contributor struct {
+		entry   ebird.Top100
+		species map[string]bool
+	}
+	byUser := make(map[string]*contributor)
+	var order []string
+	for _, c := range r.checklistsIn(r.args[0], date) {
+		u, ok := byUser[c.UserDisplayName]
+		if !ok {
+			u = &contributor{
+				entry:   ebird.Top100{UserDisplayName: c.UserDisplayName, UserId: "USER" + strconv.Itoa(len(order)+1)},
+				species: make(map[string]bool),
+			}
+			byUser[c.UserDisplayName] = u
+			order = append(order, c.UserDisplayName)
+		}
+		if c.AllObsReported {
+			u.entry.NumCompleteChecklists++
+		}
+		for _, o := range c.Obs {
+			u.species[o.SpeciesCode] = true
+		}
+	}
+
+	top := make([]ebird.Top100, 0, len(order))
+	for _, name := range order {
+		u := byUser[name]
+		u.entry.NumSpecies = len(u.species)
+		top = append(top, u.entry)
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if rankedBy == "cl" {
+			return top[i].NumCompleteChecklists > top[j].NumCompleteChecklists
+		}
+		return top[i].NumSpecies > top[j].NumSpecies
+	})
+	for i := range top {
+		top[i].RowNum = i + 1
+	}
+	return response{json: limit(top, f.maxResults)}
+}
+
+func handleSpeciesList(r *request) response {
+	region := r.args[0]
+	if err := checkRegion(region); err != nil {
+		return badRequest(err.Error())
+	}
+
+	hotspots := make(map[string]string, len(r.fixtures.Hotspots))
+	for _, h := range r.fixtures.Hotspots {
+		hotspots[h.LocId] = hotspotRegion(h)
+	}
+
+	species := make(map[string]bool)
+	for _, o := range r.fixtures.Observations {
+		obsRegion := o.RegionCode
+		if obsRegion == "" {
+			obsRegion = hotspots[o.LocId]
+		}
+		if inRegion(region, obsRegion, o.LocId) {
+			species[o.SpeciesCode] = true
+		}
+	}
+	for _, c := range r.checklistsIn(region, time.Time{}) {
+		for _, o := range c.Obs {
+			species[o.SpeciesCode] = true
+		}
+	}
+
+	order := make(map[string]float64, len(r.fixtures.Taxonomy))
+	for _, t := range r.fixtures.Taxonomy {
+		order[t.SpeciesCode] = t.TaxonOrder
+	}
+	codes := make([]string, 0, len(species))
+	for code := range species {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		oi, iok := order[codes[i]]
+		oj, jok := order[codes[j]]
+		if iok != jok {
+			return iok
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		return codes[i] < codes[j]
+	})
+	return response{json: codes}
+}
+
+func (r *request) regionName(code string) string {
+	for _, region := range r.fixtures.Regions {
+		if region.Code == code {
+			return region.Name
+		}
+	}
+	return ""
+}
+
+func handleAdjacentRegions(r *request) response {
+	code := r.args[0]
+	if err := checkRegion(code); err != nil {
+		return badRequest(err.Error())
+	}
+
+	var codes []string
+	for _, region := range r.fixtures.Regions {
+		if region.Code == code {
+			codes = append(codes, region.Adjacent...)
+		} else if contains(region.Adjacent, code) {
+			codes = append(codes, region.Code)
+		}
+	}
+	sort.Strings(codes)
+
+	regions := []ebird.AdjacentRegion{}
+	for i, c := range codes {
+		if i > 0 && c == codes[i-1] {
+			continue
+		}
+		regions = append(regions, ebird.AdjacentRegion{Code: c, Name: r.regionName(c)})
+	}
+
+	if r.query.Get("fmt") == "csv" {
+		rows := [][]string{{"code", "name"}}
+		for _, region := range regions {
+			rows = append(rows, []string{region.Code, region.Name})
+		}
+		return response{csv: csvBody(rows)}
+	}
+	return response{json: regions}
+}
+
+func handleHotspotInfo(r *request) response {
+	for _, h := range r.fixtures.Hotspots {
+		if h.LocId == r.args[0] {
+			return response{json: r.hotspotInfo(h.LocId, h.LocName, "")}
+		}
+	}
+	return notFound("Hotspot " + r.args[0] + " not found")
+}
+
+// hotspots filters the hotspot fixtures by recent activity. Hotspots are
+// only filtered by Back when the parameter is given.
+func (r *request) hotspots(f filters, keep func(ebird.HotspotInRegion) bool) []ebird.HotspotInRegion {
+	var out []ebird.HotspotInRegion
+	for _, h := range r.fixtures.Hotspots {
+		if !keep(h) {
+			continue
+		}
+		if r.query.Get("back") != "" {
+			t, ok := parseObsDate(h.LatestObsDt)
+			if !ok || !withinBack(t, r.now, f.back) {
+				continue
+			}
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// hotspotResponse writes hotspots as CSV, the API's default for hotspot
+// endpoints, unless fmt=json is given.
+func hotspotResponse(r *request, hotspots []ebird.HotspotInRegion, nearby bool) response {
+	if r.query.Get("fmt") != "json" {
+		rows := make([][]string, 0, len(hotspots))
+		for _, h := range hotspots {
+			row := []string{
+				h.LocId, h.CountryCode, h.Subnational1Code, h.Subnational2Code,
+				strconv.FormatFloat(h.Lat, 'f', -1, 64), strconv.FormatFloat(h.Lng, 'f', -1, 64), h.LocName,
+			}
+			if h.LatestObsDt != "" {
+				row = append(row, h.LatestObsDt, strconv.Itoa(h.NumSpeciesAllTime))
+			}
+			rows = append(rows, row)
+		}
+		return response{csv: csvBody(rows)}
+	}
+
+	if !nearby {
+		return response{json: nonNil(hotspots)}
+	}
+	out := make([]ebird.NearbyHotspot, 0, len(hotspots))
+	for _, h := range hotspots {
+		out = append(out, ebird.NearbyHotspot{
+			LocId:             h.LocId,
+			LocName:           h.LocName,
+			CountryCode:       h.CountryCode,
+			Subnational1Code:  h.Subnational1Code,
+			Subnational2Code:  h.Subnational2Code,
+			Lat:               h.Lat,
+			Lng:               h.Lng,
+			LatestObsDt:       h.LatestObsDt,
+			NumSpeciesAllTime: h.NumSpeciesAllTime,
+		})
+	}
+	return response{json: out}
+}
+
+func handleHotspotsInRegion(r *request) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	region := r.args[0]
+	if err := checkRegion(region); err != nil {
+		return badRequest(err.Error())
+	}
+
+	hotspots := r.hotspots(f, func(h ebird.HotspotInRegion) bool {
+		return inRegion(region, hotspotRegion(h), h.LocId)
+	})
+	return hotspotResponse(r, hotspots, false)
+}
+
+func handleNearbyHotspots(r *request) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	if !f.hasGeo {
+		return badRequest("lat and lng are required")
+	}
+	if f.dist < 0 {
+		f.dist = defaultDist
+	}
+
+	hotspots := r.hotspots(f, func(h ebird.HotspotInRegion) bool {
+		return distanceKm(f.lat, f.lng, h.Lat, h.Lng) <= f.dist
+	})
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		return distanceKm(f.lat, f.lng, hotspots[i].Lat, hotspots[i].Lng) < distanceKm(f.lat, f.lng, hotspots[j].Lat, hotspots[j].Lng)
+	})
+	return hotspotResponse(r, hotspots, true)
+}
+
+func handleTaxonomy(r *request) response {
+	f, err := r.filters()
+	if err != nil {
+		return badRequest(err.Error())
+	}
+
+	taxa := []ebird.EbirdTaxon{}
+	for _, t := range r.fixtures.Taxonomy {
+		if len(f.species) > 0 && !contains(f.species, t.SpeciesCode) {
+			continue
+		}
+		if len(f.cat) > 0 && !contains(f.cat, t.Category) {
+			continue
+		}
+		taxa = append(taxa, t)
+	}
+
+	if r.query.Get("fmt") == "json" {
+		return response{json: taxa}
+	}
+
+	rows := [][]string{{
+		"SCIENTIFIC_NAME", "COMMON_NAME", "SPECIES_CODE", "CATEGORY", "TAXON_ORDER",
+		"COM_NAME_CODES", "SCI_NAME_CODES", "BANDING_CODES", "ORDER",
+		"FAMILY_COM_NAME", "FAMILY_SCI_NAME", "FAMILY_CODE",
+	}}
+	for _, t := range taxa {
+		rows = append(rows, []string{
+			t.SciName, t.ComName, t.SpeciesCode, t.Category, strconv.FormatFloat(t.TaxonOrder, 'f', -1, 64),
+			strings.Join(t.ComNameCodes, " "), strings.Join(t.SciNameCodes, " "), strings.Join(t.BandingCodes, " "), t.Order,
+			t.FamilyComName, t.FamilySciName, t.FamilyCode,
+		})
+	}
+	return response{csv: csvBody(rows)}
+}
+
+func handleRegionInfo(r *request) response {
+	code := r.args[0]
+	var region *Region
+	for i := range r.fixtures.Regions {
+		if strings.EqualFold(r.fixtures.Regions[i].Code, code) {
+			region = &r.fixtures.Regions[i]
+			break
+		}
+	}
+	if region == nil {
+		return notFound("Region " + code + " not found")
+	}
+
+	delim := r.query.Get("delim")
+	if delim == "" {
+		delim = ", "
+	}
+	names := []string{region.Name}
+	if r.query.Get("regionNameFormat") != "nameonly" {
+		for parent, ok := ebird.RegionCode(region.Code).Parent(); ok; parent, ok = parent.Parent() {
+			if name := r.regionName(parent.String()); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	b := region.Bounds
+	return response{json: ebird.RegionInfo{
+		Result:    strings.Join(names, delim),
+		Code:      region.Code,
+		Type:      string(ebird.RegionCode(region.Code).Type()),
+		Bounds:    b,
+		Latitude:  (b.MinY + b.MaxY) / 2,
+		Longitude: (b.MinX + b.MaxX) / 2,
+	}}
+}
+
+func handleSubRegionList(r *request) response {
+	regionType, parent := r.args[0], r.args[1]
+	switch ebird.RegionType(regionType) {
+	case ebird.RegionTypeCountry, ebird.RegionTypeSubnational1, ebird.RegionTypeSubnational2:
+	default:
+		return badRequest(fmt.Sprintf("Unknown region type %q", regionType))
+	}
+	parentCode, err := ebird.ParseRegionCode(parent)
+	if err != nil {
+		return badRequest(fmt.Sprintf("Invalid region code %q", parent))
+	}
+
+	regions := []ebird.SubRegion{}
+	for _, region := range r.fixtures.Regions {
+		code := ebird.RegionCode(region.Code)
+		if string(code.Type()) == regionType && code != parentCode && parentCode.Contains(code) {
+			regions = append(regions, ebird.SubRegion{Code: region.Code, Name: region.Name})
+		}
+	}
+
+	if r.query.Get("fmt") == "csv" {
+		rows := [][]string{{"code", "name"}}
+		for _, region := range regions {
+			rows = append(rows, []string{region.Code, region.Name})
+		}
+		return response{csv: csvBody(rows)}
+	}
+	return response{json: regions}
+}
+
+func csvBody(rows [][]string) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.WriteAll(rows)
+	if buf.Len() == 0 {
+		return []byte{}
+	}
+	return buf.Bytes()
+}
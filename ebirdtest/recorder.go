@@ -0,0 +1,313 @@
+package ebirdtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode selects what a Recorder does with requests.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and never touches the
+	// network. Requests without a recorded interaction fail with
+	// ErrInteractionNotFound.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests upstream and writes every interaction to
+	// the cassette, replacing its previous contents.
+	ModeRecord
+	// ModePassthrough sends requests upstream without recording them.
+	ModePassthrough
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModePassthrough:
+		return "passthrough"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode parses "replay", "record" or "passthrough", so the mode can come
+// from an environment variable or test flag. An empty string is ModeReplay.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return ModeReplay, nil
+	}
+	for _, m := range []Mode{ModeReplay, ModeRecord, ModePassthrough} {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown recorder mode %q", s)
+}
+
+// ErrInteractionNotFound is returned in replay mode for requests that are
+// not in the cassette.
+var ErrInteractionNotFound = errors.New("no recorded interaction")
+
+const redacted = "REDACTED"
+
+// Cassette is the on-disk form of recorded interactions. It is written as
+// indented JSON, with JSON bodies embedded as values and other bodies split
+// into lines, so re-recording shows up as a readable diff.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as matched during replay. Query is the
+// normalized query string without the key parameter.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// RecordedResponse is a recorded response. Exactly one of JSON and Body is
+// set.
+type RecordedResponse struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	JSON   json.RawMessage `json:"json,omitempty"`
+	Body   []string        `json:"body,omitempty"`
+}
+
+func (r RecordedResponse) body() []byte {
+	if r.JSON != nil {
+		return r.JSON
+	}
+	return []byte(strings.Join(r.Body, "\n"))
+}
+
+// Recorder is an http.RoundTripper that records and replays eBird API
+// traffic. Use it with ebird.WithHTTPClient:
+//
+//	rec, err := ebirdtest.NewRecorder("testdata/recent.json", ebirdtest.ModeReplay)
+//	client, err := ebird.NewClient(key, ebird.WithHTTPClient(rec.Client()))
+//
+// The X-eBirdApiToken header and key query parameter are redacted before
+// anything is written.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     map[int]bool
+}
+
+type RecorderOption func(*Recorder)
+
+// WithTransport sets the transport used in record and passthrough modes.
+// It defaults to http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// NewRecorder returns a recorder for the cassette at path. In replay mode
+// the cassette must exist.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+		used:      make(map[int]bool),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Mode returns the recorder's mode.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that uses the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeReplay:
+		return r.replay(req)
+	case ModeRecord:
+		return r.record(req)
+	default:
+		return r.transport.RoundTrip(req)
+	}
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	method, path, query := matchKey(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Each interaction is used once, in order, so repeated requests can
+	// return different responses. Once all matches are used the last one
+	// keeps answering.
+	last := -1
+	for i, in := range r.cassette.Interactions {
+		if in.Request.Method != method || in.Request.Path != path || in.Request.Query != query {
+			continue
+		}
+		last = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("%w for %s %s?%s in %s", ErrInteractionNotFound, method, path, query, r.path)
+	}
+	r.used[last] = true
+
+	recorded := r.cassette.Interactions[last].Response
+	body := recorded.body()
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	method, path, query := matchKey(req)
+	header := req.Header.Clone()
+	if header.Get("X-eBirdApiToken") != "" {
+		header.Set("X-eBirdApiToken", redacted)
+	}
+	in := Interaction{
+		Request: RecordedRequest{Method: method, Path: path, Query: query, Header: header},
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: responseHeader(resp.Header),
+		},
+	}
+	var indented bytes.Buffer
+	if json.Valid(body) && json.Indent(&indented, body, "", "  ") == nil {
+		in.Response.JSON = indented.Bytes()
+	} else {
+		in.Response.Body = strings.Split(string(body), "\n")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the cassette. The caller holds r.mu.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// responseHeader keeps the response headers that matter to the client and
+// drops ones that change on every request.
+func responseHeader(h http.Header) http.Header {
+	kept := make(http.Header)
+	for _, name := range []string{"Content-Type", "Retry-After"} {
+		if v := h.Values(name); len(v) > 0 {
+			kept[name] = v
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// matchKey returns the method, path and normalized query of req. The query
+// has its parameters and values sorted and the key parameter removed, so
+// option order does not affect matching.
+func matchKey(req *http.Request) (string, string, string) {
+	values := req.URL.Query()
+	values.Del("key")
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	return req.Method, req.URL.Path, strings.Join(parts, "&")
+}
@@ -0,0 +1,90 @@
+package ebirdtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/siansiansu/go-ebird"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	srv := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	rec, err := NewRecorder(path, ModeRecord)
+	require.NoError(t, err)
+	client, err := ebird.NewClient(APIKey, ebird.WithBaseURL(srv.URL), ebird.WithHTTPClient(rec.Client()))
+	require.NoError(t, err)
+
+	recorded, err := client.RecentObservationsInRegion(ctx, "US-NY", ebird.Back(7), ebird.MaxResults(5))
+	require.NoError(t, err)
+	hotspots, err := client.HotspotsInRegion(ctx, "US-NY", ebird.Fmt("csv"))
+	require.NoError(t, err)
+	_, err = client.ViewChecklist(ctx, "S999")
+	assert.ErrorIs(t, err, ebird.ErrNotFound)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), APIKey)
+	assert.Contains(t, string(data), `"X-Ebirdapitoken": [`)
+	assert.Contains(t, string(data), `"speciesCode": "snoowl1"`)
+	srv.Close()
+
+	rec, err = NewRecorder(path, ModeReplay)
+	require.NoError(t, err)
+	client, err = ebird.NewClient("other-key", ebird.WithBaseURL(srv.URL), ebird.WithHTTPClient(rec.Client()))
+	require.NoError(t, err)
+
+	replayed, err := client.RecentObservationsInRegion(ctx, "US-NY", ebird.MaxResults(5), ebird.Back(7))
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	replayedHotspots, err := client.HotspotsInRegion(ctx, "US-NY", ebird.Fmt("csv"))
+	require.NoError(t, err)
+	assert.Equal(t, hotspots, replayedHotspots)
+
+	_, err = client.ViewChecklist(ctx, "S999")
+	assert.ErrorIs(t, err, ebird.ErrNotFound)
+
+	_, err = client.RecentObservationsInRegion(ctx, "US-NY")
+	assert.ErrorIs(t, err, ErrInteractionNotFound)
+	assert.Contains(t, err.Error(), "GET /data/obs/US-NY/recent?")
+}
+
+func TestRecorderPassthrough(t *testing.T) {
+	srv := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := NewRecorder(path, ModePassthrough)
+	require.NoError(t, err)
+	client := srv.Client(ebird.WithHTTPClient(rec.Client()))
+	_, err = client.TaxonomyVersions(context.Background())
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, path)
+	assert.Len(t, srv.Requests(), 1)
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.Error(t, err)
+}
+
+func TestParseMode(t *testing.T) {
+	for _, m := range []Mode{ModeReplay, ModeRecord, ModePassthrough} {
+		got, err := ParseMode(m.String())
+		require.NoError(t, err)
+		assert.Equal(t, m, got)
+	}
+	mode, err := ParseMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ModeReplay, mode)
+
+	_, err = ParseMode("rewind")
+	assert.Error(t, err)
+}
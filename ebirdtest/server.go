@@ -0,0 +1,329 @@
+// Package ebirdtest provides an in-process fake of the eBird API for tests.
+//
+// A Server implements every path in ebird.APIEndpoints from a set of
+// Fixtures and applies the common query filters, so tests can exercise real
+// *ebird.Client calls without hand-written JSON:
+//
+//	srv := ebirdtest.NewServer(&ebirdtest.Fixtures{
+//		Observations: []ebirdtest.Observation{{
+//			Observation: ebird.Observation{SpeciesCode: "amecro", ObsDt: "2024-05-01 08:00", SubId: "S1"},
+//			RegionCode:  "US-NY-109",
+//		}},
+//	}, ebirdtest.WithNow(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)))
+//	defer srv.Close()
+//
+//	obs, err := srv.Client().RecentObservationsInRegion(ctx, "US-NY")
+//
+// Faults such as errors, latency and 429 responses can be injected per
+// endpoint.
+package ebirdtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+// APIKey is the key a Server accepts unless WithAPIKey sets another.
+const APIKey = "test-api-key"
+
+// Server is a running fake eBird API.
+type Server struct {
+	// URL is the base URL of the server, ending in a slash, for
+	// ebird.WithBaseURL.
+	URL string
+
+	server *httptest.Server
+	apiKey string
+	now    func() time.Time
+
+	mu       sync.Mutex
+	fixtures Fixtures
+	faults   []*fault
+	latency  time.Duration
+	requests []Request
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	// Endpoint is the APIEndpoints field name, such as
+	// "RecentObservationsInRegion", or "" for unknown paths.
+	Endpoint string
+	Path     string
+	Query    url.Values
+	Header   http.Header
+}
+
+// Fault is a failure injected into responses.
+type Fault struct {
+	// Status is the HTTP status to return. Zero responds normally after
+	// Latency.
+	Status int
+	// Message is the error title in the response body. It defaults to the
+	// status text.
+	Message string
+	// RetryAfter sets the Retry-After header.
+	RetryAfter time.Duration
+	// Latency delays the response.
+	Latency time.Duration
+	// Times is how many requests the fault applies to. Zero means every
+	// request until ClearFaults.
+	Times int
+}
+
+type fault struct {
+	endpoint string
+	Fault
+}
+
+type Option func(*Server)
+
+// WithAPIKey sets the key the server accepts in the X-eBirdApiToken header
+// or the key query parameter.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithNow sets the clock the Back filters are relative to. Fixed times keep
+// fixtures with absolute dates working.
+func WithNow(now time.Time) Option {
+	return func(s *Server) {
+		s.now = func() time.Time { return now }
+	}
+}
+
+// NewServer starts a server that answers from fixtures. The caller should
+// call Close when done.
+func NewServer(fixtures *Fixtures, opts ...Option) *Server {
+	s := &Server{
+		apiKey: APIKey,
+		now:    time.Now,
+	}
+	if fixtures != nil {
+		s.fixtures = *fixtures
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL + "/"
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the server using its API key.
+func (s *Server) Client(opts ...ebird.ClientOption) *ebird.Client {
+	client, err := ebird.NewClient(s.apiKey, append([]ebird.ClientOption{ebird.WithBaseURL(s.URL)}, opts...)...)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Update changes the fixtures while the server is running.
+func (s *Server) Update(fn func(f *Fixtures)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.fixtures)
+}
+
+// Inject adds a fault for endpoint, an APIEndpoints field name such as
+// "RecentObservationsInRegion". An empty endpoint matches every request.
+// Faults apply in the order they were added.
+func (s *Server) Inject(endpoint string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{endpoint: endpoint, Fault: f})
+}
+
+// InjectError makes the next times requests to endpoint fail with status.
+func (s *Server) InjectError(endpoint string, status, times int) {
+	s.Inject(endpoint, Fault{Status: status, Times: times})
+}
+
+// InjectRateLimit makes the next times requests to endpoint fail with 429
+// and a Retry-After header.
+func (s *Server) InjectRateLimit(endpoint string, retryAfter time.Duration, times int) {
+	s.Inject(endpoint, Fault{Status: http.StatusTooManyRequests, RetryAfter: retryAfter, Times: times})
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// ClearFaults removes all injected faults and latency.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+	s.latency = 0
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	name, ok := ebird.EndpointName(path)
+	if !ok {
+		name = ""
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: name,
+		Path:     r.URL.Path,
+		Query:    r.URL.Query(),
+		Header:   r.Header.Clone(),
+	})
+	latency := s.latency
+	f := s.takeFault(name)
+	s.mu.Unlock()
+
+	if f != nil {
+		latency += f.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if f != nil && f.Status != 0 {
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Round(time.Second)/time.Second)))
+		}
+		writeError(w, f.Status, f.Message)
+		return
+	}
+
+	if r.Header.Get("X-eBirdApiToken") != s.apiKey && r.URL.Query().Get("key") != s.apiKey {
+		writeError(w, http.StatusUnauthorized, "Invalid or missing API key")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "")
+		return
+	}
+
+	handle, ok := handlers[name]
+	if name == "" || !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No endpoint at %s", r.URL.Path))
+		return
+	}
+
+	s.mu.Lock()
+	req := &request{
+		fixtures: &s.fixtures,
+		args:     pathArgs(name, path),
+		query:    r.URL.Query(),
+		now:      s.now(),
+	}
+	resp := handle(req)
+	s.mu.Unlock()
+
+	resp.write(w)
+}
+
+// takeFault returns the first fault matching endpoint and uses up one of its
+// times. The caller holds s.mu.
+func (s *Server) takeFault(endpoint string) *Fault {
+	for i, f := range s.faults {
+		if f.endpoint != "" && f.endpoint != endpoint {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &f.Fault
+	}
+	return nil
+}
+
+// response is what a handler returns: a value to encode as JSON, a CSV body
+// or an error status.
+type response struct {
+	status  int
+	message string
+	json    interface{}
+	csv     []byte
+}
+
+func (r response) write(w http.ResponseWriter) {
+	switch {
+	case r.status != 0:
+		writeError(w, r.status, r.message)
+	case r.csv != nil:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Write(r.csv)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(r.json)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{
+			"status": strconv.Itoa(status),
+			"code":   "error",
+			"title":  message,
+		}},
+	})
+}
+
+// templates maps APIEndpoints field names to their path segments.
+var templates = func() map[string][]string {
+	v := reflect.ValueOf(ebird.APIEndpoints)
+	m := make(map[string][]string, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		m[v.Type().Field(i).Name] = strings.Split(v.Field(i).String(), "/")
+	}
+	return m
+}()
+
+// pathArgs returns the path segments that fill the placeholders of the
+// endpoint's template.
+func pathArgs(endpoint, path string) []string {
+	var args []string
+	segments := strings.Split(path, "/")
+	for i, seg := range templates[endpoint] {
+		if (seg == "%s" || seg == "%d") && i < len(segments) {
+			args = append(args, segments[i])
+		}
+	}
+	return args
+}
@@ -0,0 +1,248 @@
+package ebirdtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+
+func newTestServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+	srv := NewServer(MustLoadFixtures("testdata/fixtures.json"), append([]Option{WithNow(testNow)}, opts...)...)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func obsSpecies(obs []ebird.Observation) []string {
+	codes := make([]string, 0, len(obs))
+	for _, o := range obs {
+		codes = append(codes, o.SpeciesCode+"/"+o.SubId)
+	}
+	return codes
+}
+
+func TestServerObservations(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	obs, err := client.RecentObservationsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"snoowl1/S100", "amecro/S100"}, obsSpecies(obs))
+
+	obs, err = client.RecentObservationsInRegion(ctx, "US-NY-109")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amecro/S101"}, obsSpecies(obs))
+
+	obs, err = client.RecentObservationsInRegion(ctx, "US-NY", ebird.Back(1))
+	require.NoError(t, err)
+	assert.Empty(t, obs)
+
+	obs, err = client.RecentObservationsOfSpeciesInRegion(ctx, "US", "amecro", ebird.MaxResults(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amecro/S100"}, obsSpecies(obs))
+
+	obs, err = client.RecentNotableObservationsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"snoowl1/S100"}, obsSpecies(obs))
+
+	obs, err = client.RecentNearbyObservations(ctx, ebird.Lat(42.45), ebird.Lng(-76.5), ebird.Dist(10))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amecro/S101"}, obsSpecies(obs))
+
+	obs, err = client.NearestObservationsOfSpecies(ctx, "amecro", ebird.Lat(42.45), ebird.Lng(-76.5))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amecro/S101", "amecro/S100"}, obsSpecies(obs))
+
+	obs, err = client.HistoricObservationsOnDate(ctx, "US-NY", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"norcar/S102"}, obsSpecies(obs))
+
+	obs, err = client.HistoricObservationsOnDate(ctx, "US-NY", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), ebird.Hotspot(true))
+	require.NoError(t, err)
+	assert.Empty(t, obs)
+
+	_, err = client.RecentNearbyObservations(ctx)
+	assert.ErrorIs(t, err, ebird.ErrInvalidArgument)
+}
+
+func TestServerProducts(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	feed, err := client.RecentChecklistsFeed(ctx, "US-NY")
+	require.NoError(t, err)
+	require.Len(t, feed, 2)
+	assert.Equal(t, "S100", feed[0].SubId)
+	assert.Equal(t, "1 May 2024", feed[0].ObsDt)
+	assert.Equal(t, "08:00", feed[0].ObsTime)
+	assert.Equal(t, "Nassau", feed[0].Loc.Subnational2Name)
+
+	stats, err := client.RegionalStatisticsOnDate(ctx, "US-NY", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, ebird.RegionalStatisticsOnDate{NumChecklists: 1, NumContributors: 1, NumSpecies: 2}, *stats)
+
+	top, err := client.Top100(ctx, "US-NY", time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, "Ben Watcher", top[0].UserDisplayName)
+
+	species, err := client.SpeciesListForRegion(ctx, "US-NY")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"snoowl1", "amecro", "norcar"}, species)
+
+	checklist, err := client.ViewChecklist(ctx, "S101")
+	require.NoError(t, err)
+	assert.Equal(t, "Ben Watcher", checklist.UserDisplayName)
+
+	_, err = client.ViewChecklist(ctx, "S999")
+	assert.ErrorIs(t, err, ebird.ErrNotFound)
+}
+
+func TestServerHotspotsAndTaxonomy(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	hotspots, err := client.HotspotsInRegion(ctx, "US-NY-109")
+	require.NoError(t, err)
+	require.Len(t, hotspots, 1)
+	assert.Equal(t, "L99381", hotspots[0].LocId)
+
+	hotspots, err = client.HotspotsInRegion(ctx, "US-NY", ebird.Fmt("csv"), ebird.Back(2))
+	require.NoError(t, err)
+	require.Len(t, hotspots, 1)
+	assert.Equal(t, 312, hotspots[0].NumSpeciesAllTime)
+
+	nearby, err := client.NearbyHotspots(ctx, ebird.Lat(40.6), ebird.Lng(-73.5))
+	require.NoError(t, err)
+	require.Len(t, nearby, 1)
+	assert.Equal(t, "Jones Beach SP", nearby[0].LocName)
+
+	info, err := client.HotspotInfo(ctx, "L109516")
+	require.NoError(t, err)
+	assert.Equal(t, "US-NY-059", info.Subnational2Code)
+	assert.Equal(t, "Jones Beach SP, Nassau, New York, United States", info.HierarchicalName)
+
+	taxa, err := client.EbirdTaxonomy(ctx, ebird.Cat("spuh"))
+	require.NoError(t, err)
+	require.Len(t, taxa, 1)
+	assert.Equal(t, "y00494", taxa[0].SpeciesCode)
+
+	taxa, err = client.EbirdTaxonomy(ctx, ebird.Species("amecro"), ebird.Fmt("csv"))
+	require.NoError(t, err)
+	require.Len(t, taxa, 1)
+	assert.Equal(t, []string{"AMCR"}, taxa[0].BandingCodes)
+
+	versions, err := client.TaxonomyVersions(ctx)
+	require.NoError(t, err)
+	assert.True(t, versions[0].Latest)
+
+	_, err = client.TaxonomicGroups(ctx, "other")
+	assert.ErrorIs(t, err, ebird.ErrInvalidArgument)
+}
+
+func TestServerRegions(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	info, err := client.RegionInfo(ctx, "US-NY-109")
+	require.NoError(t, err)
+	assert.Equal(t, "Tompkins, New York, United States", info.Result)
+
+	info, err = client.RegionInfo(ctx, "US-NY-109", ebird.RegionNameFormat("nameonly"))
+	require.NoError(t, err)
+	assert.Equal(t, "Tompkins", info.Result)
+
+	subregions, err := client.SubRegionList(ctx, "subnational2", "US-NY")
+	require.NoError(t, err)
+	assert.Equal(t, []ebird.SubRegion{{Code: "US-NY-059", Name: "Nassau"}, {Code: "US-NY-109", Name: "Tompkins"}}, subregions)
+
+	adjacent, err := client.AdjacentRegions(ctx, "US-NJ")
+	require.NoError(t, err)
+	assert.Equal(t, []ebird.AdjacentRegion{{Code: "US-NY", Name: "New York"}}, adjacent)
+
+	_, err = client.RegionInfo(ctx, "CA")
+	assert.ErrorIs(t, err, ebird.ErrNotFound)
+}
+
+func TestServerAuth(t *testing.T) {
+	srv := newTestServer(t, WithAPIKey("secret"))
+
+	_, err := srv.Client().TaxonomyVersions(context.Background())
+	require.NoError(t, err)
+
+	client, err := ebird.NewClient("wrong", ebird.WithBaseURL(srv.URL))
+	require.NoError(t, err)
+	_, err = client.TaxonomyVersions(context.Background())
+	assert.ErrorIs(t, err, ebird.ErrUnauthorized)
+
+	requests := srv.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "TaxonomyVersions", requests[1].Endpoint)
+	assert.Equal(t, "wrong", requests[1].Header.Get("X-eBirdApiToken"))
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.InjectRateLimit("RecentObservationsInRegion", 3*time.Second, 1)
+	_, err := client.RecentObservationsInRegion(ctx, "US-NY")
+	assert.ErrorIs(t, err, ebird.ErrRateLimited)
+	var apiErr ebird.Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 3*time.Second, apiErr.RetryAfter)
+
+	_, err = client.RecentObservationsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+
+	srv.InjectError("", http.StatusInternalServerError, 0)
+	_, err = client.TaxonomyVersions(ctx)
+	assert.ErrorIs(t, err, ebird.ErrServer)
+	_, err = client.TaxonomyVersions(ctx)
+	assert.ErrorIs(t, err, ebird.ErrServer)
+	srv.ClearFaults()
+
+	srv.Inject("TaxonomyVersions", Fault{Latency: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	_, err = client.TaxonomyVersions(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	srv.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = client.TaxonomyVersions(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServerUpdate(t *testing.T) {
+	srv := NewServer(nil, WithNow(testNow))
+	defer srv.Close()
+
+	obs, err := srv.Client().RecentObservationsInRegion(context.Background(), "US")
+	require.NoError(t, err)
+	assert.Empty(t, obs)
+
+	srv.Update(func(f *Fixtures) {
+		f.Observations = append(f.Observations, Observation{
+			Observation: ebird.Observation{SpeciesCode: "gyrfal", SubId: "S1", ObsDt: "2024-05-02 07:00"},
+			RegionCode:  "US-ME",
+		})
+	})
+	obs, err = srv.Client().RecentObservationsInRegion(context.Background(), "US")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gyrfal/S1"}, obsSpecies(obs))
+}
@@ -0,0 +1,68 @@
+package ebird
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Sentinel errors for classifying failures with errors.Is.
+var (
+	ErrUnauthorized    = errors.New("ebird: unauthorized")
+	ErrNotFound        = errors.New("ebird: not found")
+	ErrRateLimited     = errors.New("ebird: rate limited")
+	ErrServer          = errors.New("ebird: server error")
+	ErrInvalidArgument = errors.New("ebird: invalid argument")
+)
+
+// Is reports whether the API error belongs to the class of target, so that
+// errors.Is(err, ErrNotFound) works on wrapped API errors.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized || e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrServer:
+		return e.Status >= http.StatusInternalServerError
+	case ErrInvalidArgument:
+		return e.Status == http.StatusBadRequest || e.Status == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+type argumentError struct {
+	message string
+}
+
+func invalidArgument(message string) error {
+	return &argumentError{message: message}
+}
+
+func (e *argumentError) Error() string {
+	return e.message
+}
+
+func (e *argumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+var redactedParams = []string{"key", "token"}
+
+func redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	changed := false
+	for _, name := range redactedParams {
+		if query.Has(name) {
+			query.Set(name, "REDACTED")
+			changed = true
+		}
+	}
+	if changed {
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.String()
+}
@@ -0,0 +1,171 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    error
+		message string
+	}{
+		{
+			name:    "Unauthorized",
+			status:  http.StatusUnauthorized,
+			body:    `{"errors":[{"status":"401 UNAUTHORIZED","code":"error.auth","title":"Invalid API key"}]}`,
+			want:    ErrUnauthorized,
+			message: "Invalid API key",
+		},
+		{
+			name:    "Forbidden",
+			status:  http.StatusForbidden,
+			want:    ErrUnauthorized,
+			message: "unexpected HTTP 403: Forbidden (body empty)",
+		},
+		{
+			name:    "Not found",
+			status:  http.StatusNotFound,
+			body:    `{"error":{"message":"Region not found"}}`,
+			want:    ErrNotFound,
+			message: "Region not found",
+		},
+		{
+			name:    "Rate limited",
+			status:  http.StatusTooManyRequests,
+			body:    `{"error":"Too many requests"}`,
+			want:    ErrRateLimited,
+			message: "Too many requests",
+		},
+		{
+			name:    "Server error",
+			status:  http.StatusServiceUnavailable,
+			body:    `<html>down for maintenance</html>`,
+			want:    ErrServer,
+			message: "unexpected HTTP 503: Service Unavailable",
+		},
+		{
+			name:    "Bad request",
+			status:  http.StatusBadRequest,
+			body:    `{"error":{"message":"Invalid region code","status":400}}`,
+			want:    ErrInvalidArgument,
+			message: "Invalid region code",
+		},
+	}
+
+	sentinels := []error{ErrUnauthorized, ErrNotFound, ErrRateLimited, ErrServer, ErrInvalidArgument}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+			require.NoError(t, err)
+
+			_, err = client.RecentObservationsInRegion(context.Background(), "US-NY")
+			require.Error(t, err)
+
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tt.want, errors.Is(err, sentinel), sentinel.Error())
+			}
+
+			var apiErr Error
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.status, apiErr.Status)
+			assert.Equal(t, tt.message, apiErr.Message)
+			assert.Equal(t, "RecentObservationsInRegion", apiErr.Endpoint)
+		})
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	_, err = client.HotspotInfo(context.Background(), "L123", func(o *RequestOptions) {
+		o.URLParams.Set("key", "secret")
+	})
+	require.Error(t, err)
+
+	var apiErr Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "HotspotInfo", apiErr.Endpoint)
+	assert.Equal(t, 30*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, server.URL+"/ref/hotspot/info/L123?key=REDACTED", apiErr.URL)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestInvalidArgumentErrors(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.ViewChecklist(ctx, "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.EqualError(t, err, "subId cannot be empty")
+
+	_, err = client.RecentNearbyObservations(ctx)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestEndpointName(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"data/obs/US-NY/recent", "RecentObservationsInRegion"},
+		{"data/obs/US-NY/recent/notable", "RecentNotableObservationsInRegion"},
+		{"data/obs/US-NY/recent/amecro", "RecentObservationsOfSpeciesInRegion"},
+		{"data/obs/geo/recent", "RecentNearbyObservations"},
+		{"data/obs/geo/recent/notable", "RecentNearbyNotableObservations"},
+		{"data/obs/geo/recent/amecro", "RecentNearbyObservationsOfSpecies"},
+		{"data/obs/US-NY/historic/2024/5/1", "HistoricObservationsOnDate"},
+		{"product/lists/US-NY", "RecentChecklistsFeed"},
+		{"product/lists/US-NY/2024/5/1", "ChecklistFeedOnDate"},
+		{"ref/hotspot/geo", "NearbyHotspots"},
+		{"ref/hotspot/US-NY", "HotspotsInRegion"},
+		{"ref/hotspot/info/L123", "HotspotInfo"},
+		{"ref/region/list/subnational1/US", "SubRegionInfo"},
+		{"ref/taxonomy/ebird", "EbirdTaxonomy"},
+		{"test", "test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointName(tt.endpoint))
+
+			name, ok := EndpointName("/" + tt.endpoint)
+			assert.Equal(t, tt.want, name)
+			assert.Equal(t, tt.want != tt.endpoint, ok)
+		})
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, err := url.Parse("https://api.ebird.org/v2/data/obs/US/recent?back=5&key=abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.ebird.org/v2/data/obs/US/recent?back=5&key=REDACTED", redactURL(u))
+	assert.Equal(t, "https://api.ebird.org/v2/data/obs/US/recent?back=5&key=abc", u.String())
+}
@@ -25,8 +25,13 @@ func WithFormat(format string) AdjacentRegionsOption {
 
 func (c *Client) AdjacentRegions(ctx context.Context, regionCode string, opts ...AdjacentRegionsOption) ([]AdjacentRegion, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
 	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacent regions: %w", err)
+	}
+	regionCode = code.String()
 
 	options := &AdjacentRegionsOptions{}
 	for _, opt := range opts {
@@ -41,7 +46,7 @@ func (c *Client) AdjacentRegions(ctx context.Context, regionCode string, opts ..
 	endpoint := fmt.Sprintf(APIEndpoints.AdjacentRegions, regionCode)
 
 	var regions []AdjacentRegion
-	err := c.get(ctx, endpoint, params, &regions)
+	err = c.get(ctx, endpoint, RequestOptions{URLParams: params}, &regions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get adjacent regions: %w", err)
 	}
@@ -0,0 +1,171 @@
+package ebird
+
+import (
+	"context"
+	"sort"
+)
+
+type groupBound struct {
+	lower, upper float64
+	group        int
+}
+
+// GroupIndex maps taxa to the species groups returned by TaxonomicGroups,
+// using each group's TaxonOrderBounds. Lookups by species code need the
+// Taxonomy the index was built with.
+type GroupIndex struct {
+	groups   []TaxonomicGroup
+	bounds   []groupBound
+	taxonomy *Taxonomy
+}
+
+// SpeciesGroup is a set of species codes in the same group, in taxonomic
+// order.
+type SpeciesGroup struct {
+	Group        TaxonomicGroup
+	SpeciesCodes []string
+}
+
+// ObservationGroup is a set of observations in the same group, in taxonomic
+// order.
+type ObservationGroup struct {
+	Group        TaxonomicGroup
+	Observations []Observation
+}
+
+// NewGroupIndex builds an index from the result of TaxonomicGroups. tax may
+// be nil if only GroupOf is used.
+func NewGroupIndex(groups []TaxonomicGroup, tax *Taxonomy) *GroupIndex {
+	g := &GroupIndex{
+		groups:   make([]TaxonomicGroup, len(groups)),
+		taxonomy: tax,
+	}
+	copy(g.groups, groups)
+	sort.SliceStable(g.groups, func(i, j int) bool {
+		return g.groups[i].GroupOrder < g.groups[j].GroupOrder
+	})
+
+	for i, group := range g.groups {
+		for _, b := range group.TaxonOrderBounds {
+			if len(b) != 2 {
+				continue
+			}
+			g.bounds = append(g.bounds, groupBound{lower: b[0], upper: b[1], group: i})
+		}
+	}
+	sort.Slice(g.bounds, func(i, j int) bool {
+		return g.bounds[i].lower < g.bounds[j].lower
+	})
+	return g
+}
+
+// LoadGroupIndex fetches the groups for speciesGrouping ("merlin" or
+// "ebird") and indexes them against tax. Use GroupNameLocale to get
+// localized group names.
+func (c *Client) LoadGroupIndex(ctx context.Context, speciesGrouping string, tax *Taxonomy, opts ...RequestOption) (*GroupIndex, error) {
+	groups, err := c.TaxonomicGroups(ctx, speciesGrouping, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewGroupIndex(groups, tax), nil
+}
+
+// Groups returns the groups in GroupOrder.
+func (g *GroupIndex) Groups() []TaxonomicGroup {
+	return append([]TaxonomicGroup(nil), g.groups...)
+}
+
+// GroupOf returns the group whose bounds contain the taxon's TaxonOrder.
+func (g *GroupIndex) GroupOf(taxon EbirdTaxon) (TaxonomicGroup, bool) {
+	return g.groupOfOrder(taxon.TaxonOrder)
+}
+
+// GroupOfCode returns the group of a species code.
+func (g *GroupIndex) GroupOfCode(speciesCode string) (TaxonomicGroup, bool) {
+	if g.taxonomy == nil {
+		return TaxonomicGroup{}, false
+	}
+	taxon, ok := g.taxonomy.ByCode(speciesCode)
+	if !ok {
+		return TaxonomicGroup{}, false
+	}
+	return g.GroupOf(taxon)
+}
+
+// GroupOfObservation returns the group of an observation's species.
+func (g *GroupIndex) GroupOfObservation(obs Observation) (TaxonomicGroup, bool) {
+	return g.GroupOfCode(obs.SpeciesCode)
+}
+
+func (g *GroupIndex) groupOfOrder(order float64) (TaxonomicGroup, bool) {
+	i := sort.Search(len(g.bounds), func(i int) bool {
+		return g.bounds[i].lower > order
+	}) - 1
+	if i < 0 || order > g.bounds[i].upper {
+		return TaxonomicGroup{}, false
+	}
+	return g.groups[g.bounds[i].group], true
+}
+
+// GroupCodes buckets species codes, such as the result of
+// SpeciesListForRegion, by group. Groups are returned in GroupOrder and
+// codes within a group in taxonomic order. Codes that are not in the
+// taxonomy or fall outside every group are returned in ungrouped.
+func (g *GroupIndex) GroupCodes(codes []string) (groups []SpeciesGroup, ungrouped []string) {
+	byGroup := make(map[string]int)
+	for _, code := range codes {
+		group, ok := g.GroupOfCode(code)
+		if !ok {
+			ungrouped = append(ungrouped, code)
+			continue
+		}
+		i, ok := byGroup[group.GroupName]
+		if !ok {
+			i = len(groups)
+			byGroup[group.GroupName] = i
+			groups = append(groups, SpeciesGroup{Group: group})
+		}
+		groups[i].SpeciesCodes = append(groups[i].SpeciesCodes, code)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Group.GroupOrder < groups[j].Group.GroupOrder
+	})
+	for _, group := range groups {
+		g.taxonomy.SortCodes(group.SpeciesCodes)
+	}
+	return groups, ungrouped
+}
+
+// GroupObservations buckets observations, such as those of a ViewChecklist,
+// by group in the same way as GroupCodes.
+func (g *GroupIndex) GroupObservations(observations []Observation) (groups []ObservationGroup, ungrouped []Observation) {
+	byGroup := make(map[string]int)
+	for _, obs := range observations {
+		group, ok := g.GroupOfObservation(obs)
+		if !ok {
+			ungrouped = append(ungrouped, obs)
+			continue
+		}
+		i, ok := byGroup[group.GroupName]
+		if !ok {
+			i = len(groups)
+			byGroup[group.GroupName] = i
+			groups = append(groups, ObservationGroup{Group: group})
+		}
+		groups[i].Observations = append(groups[i].Observations, obs)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Group.GroupOrder < groups[j].Group.GroupOrder
+	})
+	for _, group := range groups {
+		obs := group.Observations
+		sort.SliceStable(obs, func(i, j int) bool {
+			a, _ := g.taxonomy.ByCode(obs[i].SpeciesCode)
+			b, _ := g.taxonomy.ByCode(obs[j].SpeciesCode)
+			return a.TaxonOrder < b.TaxonOrder
+		})
+	}
+	return groups, ungrouped
+}
@@ -0,0 +1,84 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGroups = []TaxonomicGroup{
+	{GroupName: "Chickadees and Titmice", GroupOrder: 3, TaxonOrderBounds: [][]float64{{31000, 31300}}},
+	{GroupName: "Ostriches", GroupOrder: 1, TaxonOrderBounds: [][]float64{{1, 1}}},
+	{GroupName: "Jays, Magpies, Crows, and Ravens", GroupOrder: 2, TaxonOrderBounds: [][]float64{{19000, 20500}, {40000, 40010}}},
+}
+
+func TestGroupIndex(t *testing.T) {
+	g := NewGroupIndex(testGroups, NewTaxonomy(testTaxa))
+
+	var names []string
+	for _, group := range g.Groups() {
+		names = append(names, group.GroupName)
+	}
+	assert.Equal(t, []string{"Ostriches", "Jays, Magpies, Crows, and Ravens", "Chickadees and Titmice"}, names)
+
+	group, ok := g.GroupOf(EbirdTaxon{TaxonOrder: 40005})
+	require.True(t, ok)
+	assert.Equal(t, 2, group.GroupOrder)
+	_, ok = g.GroupOf(EbirdTaxon{TaxonOrder: 30000})
+	assert.False(t, ok)
+
+	group, ok = g.GroupOfCode("tuftit")
+	require.True(t, ok)
+	assert.Equal(t, "Chickadees and Titmice", group.GroupName)
+	_, ok = g.GroupOfCode("unknown")
+	assert.False(t, ok)
+
+	group, ok = g.GroupOfObservation(Observation{SpeciesCode: "ostric2"})
+	require.True(t, ok)
+	assert.Equal(t, "Ostriches", group.GroupName)
+}
+
+func TestGroupCodes(t *testing.T) {
+	g := NewGroupIndex(testGroups, NewTaxonomy(testTaxa))
+
+	groups, ungrouped := g.GroupCodes([]string{"tuftit", "amecro", "unknown", "bkcchi", "ostric2"})
+	assert.Equal(t, []SpeciesGroup{
+		{Group: testGroups[1], SpeciesCodes: []string{"ostric2"}},
+		{Group: testGroups[2], SpeciesCodes: []string{"amecro"}},
+		{Group: testGroups[0], SpeciesCodes: []string{"bkcchi", "tuftit"}},
+	}, groups)
+	assert.Equal(t, []string{"unknown"}, ungrouped)
+
+	obsGroups, ungroupedObs := g.GroupObservations([]Observation{
+		{SpeciesCode: "tuftit", HowMany: 2},
+		{SpeciesCode: "bkcchi", HowMany: 5},
+		{SpeciesCode: "x00001"},
+	})
+	require.Len(t, obsGroups, 1)
+	assert.Equal(t, "Chickadees and Titmice", obsGroups[0].Group.GroupName)
+	assert.Equal(t, "bkcchi", obsGroups[0].Observations[0].SpeciesCode)
+	assert.Equal(t, "tuftit", obsGroups[0].Observations[1].SpeciesCode)
+	assert.Len(t, ungroupedObs, 1)
+}
+
+func TestLoadGroupIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ref/sppgroup/merlin", r.URL.Path)
+		assert.Equal(t, "fr", r.URL.Query().Get("groupNameLocale"))
+		w.Write([]byte(`[{"groupName":"Mésanges","groupOrder":1,"taxonOrderBounds":[[31000.0,31300.0]]}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	g, err := client.LoadGroupIndex(context.Background(), "merlin", NewTaxonomy(testTaxa), GroupNameLocale("fr"))
+	require.NoError(t, err)
+	group, ok := g.GroupOfCode("bkcchi")
+	require.True(t, ok)
+	assert.Equal(t, "Mésanges", group.GroupName)
+}
@@ -0,0 +1,188 @@
+package ebird
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultConcurrency = 4
+
+// HistoricObservation is an observation tagged with the date it was queried
+// for.
+type HistoricObservation struct {
+	Observation
+	QueryDate time.Time `json:"queryDate"`
+}
+
+// HistoricDay is the result of one per-day request made by
+// StreamHistoricObservationsRange.
+type HistoricDay struct {
+	Date         time.Time
+	Observations []Observation
+	Err          error
+}
+
+type DayError struct {
+	Date time.Time
+	Err  error
+}
+
+// RangeError reports the days of a range that could not be fetched. The
+// observations for the other days are still returned. Days left unfetched
+// because ctx was cancelled are reported with ctx's error.
+type RangeError struct {
+	Days     int
+	Failures []DayError
+}
+
+func (e *RangeError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", f.Date.Format("2006-01-02"), f.Err))
+	}
+	return fmt.Sprintf("failed to get observations for %d of %d days: %s", len(e.Failures), e.Days, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of the earliest failed day.
+func (e *RangeError) Unwrap() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e.Failures[0].Err
+}
+
+// Concurrency sets how many requests run at once for methods that fan out
+// over several calls. It is not sent to the API.
+func Concurrency(n int) RequestOption {
+	return func(o *RequestOptions) {
+		if n > 0 {
+			o.concurrency = n
+		} else {
+			o.invalid("Concurrency", n, "must be at least 1")
+		}
+	}
+}
+
+// HistoricObservationsRange calls HistoricObservationsOnDate for every day
+// from from to to inclusive and merges the results in date order. Days that
+// fail, or are not reached before ctx is cancelled, are reported in a
+// *RangeError alongside the observations of the days that succeeded.
+func (c *Client) HistoricObservationsRange(ctx context.Context, regionCode string, from, to time.Time, opts ...RequestOption) ([]HistoricObservation, error) {
+	days, err := dateRange(regionCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]HistoricDay, 0, len(days))
+	delivered := make(map[time.Time]bool, len(days))
+	for day := range c.streamHistoricDays(ctx, regionCode, days, opts...) {
+		results = append(results, day)
+		delivered[day.Date] = true
+	}
+	for _, date := range days {
+		if !delivered[date] {
+			results = append(results, HistoricDay{Date: date, Err: ctx.Err()})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Date.Before(results[j].Date)
+	})
+
+	var observations []HistoricObservation
+	rangeErr := &RangeError{Days: len(days)}
+	for _, day := range results {
+		if day.Err != nil {
+			rangeErr.Failures = append(rangeErr.Failures, DayError{Date: day.Date, Err: day.Err})
+			continue
+		}
+		for _, obs := range day.Observations {
+			observations = append(observations, HistoricObservation{Observation: obs, QueryDate: day.Date})
+		}
+	}
+
+	if len(rangeErr.Failures) > 0 {
+		return observations, rangeErr
+	}
+	return observations, nil
+}
+
+// StreamHistoricObservationsRange is like HistoricObservationsRange but
+// delivers each day as soon as it has been fetched, in completion order, so
+// that long ranges need not be held in memory. The channel is closed when
+// every day has been delivered or ctx is done.
+func (c *Client) StreamHistoricObservationsRange(ctx context.Context, regionCode string, from, to time.Time, opts ...RequestOption) (<-chan HistoricDay, error) {
+	days, err := dateRange(regionCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return c.streamHistoricDays(ctx, regionCode, days, opts...), nil
+}
+
+func (c *Client) streamHistoricDays(ctx context.Context, regionCode string, days []time.Time, opts ...RequestOption) <-chan HistoricDay {
+	concurrency := processOptions(opts...).concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	queue := make(chan time.Time)
+	out := make(chan HistoricDay)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(days); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for date := range queue {
+				observations, err := c.HistoricObservationsOnDate(ctx, regionCode, date, opts...)
+				select {
+				case out <- HistoricDay{Date: date, Observations: observations, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		for _, date := range days {
+			select {
+			case queue <- date:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+func dateRange(regionCode string, from, to time.Time) ([]time.Time, error) {
+	if regionCode == "" {
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	if _, err := ParseRegionCode(regionCode); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, from.Location())
+	if end.Before(start) {
+		return nil, invalidArgument("to cannot be before from")
+	}
+
+	var days []time.Time
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days, nil
+}
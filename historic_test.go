@@ -0,0 +1,161 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricObservationsRange(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var year, month, day int
+		_, err := fmt.Sscanf(r.URL.Path, "/data/obs/US-NY-109/historic/%d/%d/%d", &year, &month, &day)
+		require.NoError(t, err)
+
+		if day == 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `[{"speciesCode":"amecro","obsDt":"%04d-%02d-%02d 08:00","subId":"S%d"}]`, year, month, day, day)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 6, 23, 0, 0, 0, time.UTC)
+	got, err := client.HistoricObservationsRange(context.Background(), "US-NY-109", from, to, Concurrency(2))
+
+	var rangeErr *RangeError
+	require.True(t, errors.As(err, &rangeErr))
+	assert.Equal(t, 6, rangeErr.Days)
+	require.Len(t, rangeErr.Failures, 1)
+	assert.Equal(t, time.Date(2025, 5, 3, 0, 0, 0, 0, time.UTC), rangeErr.Failures[0].Date)
+	assert.ErrorIs(t, err, ErrServer)
+	assert.Contains(t, err.Error(), "failed to get observations for 1 of 6 days: 2025-05-03")
+
+	require.Len(t, got, 5)
+	for i, day := range []int{1, 2, 4, 5, 6} {
+		assert.Equal(t, time.Date(2025, 5, day, 0, 0, 0, 0, time.UTC), got[i].QueryDate)
+		assert.Equal(t, fmt.Sprintf("S%d", day), got[i].SubId)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestHistoricObservationsRangeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/2025/5/3") {
+			cancel()
+		}
+		w.Write([]byte(`[{"speciesCode":"amecro"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	got, err := client.HistoricObservationsRange(ctx, "US-NY", from, from.AddDate(0, 0, 9), Concurrency(1))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var rangeErr *RangeError
+	require.True(t, errors.As(err, &rangeErr))
+	assert.GreaterOrEqual(t, len(rangeErr.Failures), 7)
+	assert.Equal(t, 10, len(got)+len(rangeErr.Failures), "every day should be returned or reported")
+	assert.Equal(t, from.AddDate(0, 0, 9), rangeErr.Failures[len(rangeErr.Failures)-1].Date)
+}
+
+func TestHistoricObservationsRangeInvalidArguments(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+	ctx := context.Background()
+	day := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err = client.HistoricObservationsRange(ctx, "", day, day)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = client.HistoricObservationsRange(ctx, "US-NY", day, day.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = client.StreamHistoricObservationsRange(ctx, "US-NY", day, day.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestStreamHistoricObservationsRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"speciesCode":"amecro"},{"speciesCode":"blujay"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	from := time.Date(2025, 4, 28, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 3, 0, 0, 0, 0, time.UTC)
+	days, err := client.StreamHistoricObservationsRange(context.Background(), "US-NY", from, to)
+	require.NoError(t, err)
+
+	seen := make(map[time.Time]bool)
+	for day := range days {
+		require.NoError(t, day.Err)
+		assert.Len(t, day.Observations, 2)
+		seen[day.Date] = true
+	}
+	assert.Len(t, seen, 6)
+	assert.True(t, seen[time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)])
+}
+
+func TestStreamHistoricObservationsRangeCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	days, err := client.StreamHistoricObservationsRange(ctx, "US-NY", from, from.AddDate(1, 0, 0))
+	require.NoError(t, err)
+
+	<-days
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range days {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel was not closed after cancel")
+	}
+}
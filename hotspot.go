@@ -3,6 +3,8 @@ package ebird
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 )
 
 type HotspotInfo struct {
@@ -29,10 +31,13 @@ type NearbyHotspot struct {
 	LocName           string  `json:"locName,omitempty"`
 	CountryCode       string  `json:"countryCode,omitempty"`
 	Subnational1Code  string  `json:"subnational1Code,omitempty"`
+	Subnational2Code  string  `json:"subnational2Code,omitempty"`
 	Lat               float64 `json:"lat"`
 	Lng               float64 `json:"lng"`
 	LatestObsDt       string  `json:"latestObsDt,omitempty"`
 	NumSpeciesAllTime int     `json:"numSpeciesAllTime,omitempty"`
+
+	LatestObsDtParsed time.Time `json:"-"`
 }
 
 type HotspotInRegion struct {
@@ -45,18 +50,26 @@ type HotspotInRegion struct {
 	Lng               float64 `json:"lng,omitempty"`
 	LatestObsDt       string  `json:"latestObsDt,omitempty"`
 	NumSpeciesAllTime int     `json:"numSpeciesAllTime,omitempty"`
+
+	LatestObsDtParsed time.Time `json:"-"`
 }
 
 func (c *Client) HotspotsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]HotspotInRegion, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
 	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotspots in region: %w", err)
+	}
+	regionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.HotspotsInRegion, regionCode)
 	params := processOptions(opts...)
+	defaultFormat(params, "json")
 
 	var hotspots []HotspotInRegion
-	err := c.get(ctx, endpoint, params.URLParams, &hotspots)
+	err = c.get(ctx, endpoint, params, &hotspots)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hotspots in region: %w", err)
 	}
@@ -66,15 +79,14 @@ func (c *Client) HotspotsInRegion(ctx context.Context, regionCode string, opts .
 
 func (c *Client) NearbyHotspots(ctx context.Context, opts ...RequestOption) ([]NearbyHotspot, error) {
 	params := processOptions(opts...)
-
-	params.URLParams.Set("fmt", "json")
+	defaultFormat(params, "json")
 
 	if params.URLParams.Get("lat") == "" || params.URLParams.Get("lng") == "" {
-		return nil, fmt.Errorf("must provide Lat and Lng parameters")
+		return nil, invalidArgument("must provide Lat and Lng parameters")
 	}
 
 	var hotspots []NearbyHotspot
-	err := c.get(ctx, APIEndpoints.NearbyHotspots, params.URLParams, &hotspots)
+	err := c.get(ctx, APIEndpoints.NearbyHotspots, params, &hotspots)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nearby hotspots: %w", err)
 	}
@@ -82,16 +94,58 @@ func (c *Client) NearbyHotspots(ctx context.Context, opts ...RequestOption) ([]N
 	return hotspots, nil
 }
 
+// HotspotsInRegionCSV streams the hotspot list for a region as CSV without
+// decoding it. The caller must close the returned reader.
+func (c *Client) HotspotsInRegionCSV(ctx context.Context, regionCode string, opts ...RequestOption) (io.ReadCloser, error) {
+	if regionCode == "" {
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotspots in region: %w", err)
+	}
+	regionCode = code.String()
+
+	endpoint := fmt.Sprintf(APIEndpoints.HotspotsInRegion, regionCode)
+	params := processOptions(opts...)
+	params.URLParams.Set("fmt", "csv")
+
+	body, err := c.getRaw(ctx, endpoint, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotspots in region: %w", err)
+	}
+
+	return body, nil
+}
+
+// NearbyHotspotsCSV streams nearby hotspots as CSV without decoding them.
+// The caller must close the returned reader.
+func (c *Client) NearbyHotspotsCSV(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error) {
+	params := processOptions(opts...)
+	params.URLParams.Set("fmt", "csv")
+
+	if params.URLParams.Get("lat") == "" || params.URLParams.Get("lng") == "" {
+		return nil, invalidArgument("must provide Lat and Lng parameters")
+	}
+
+	body, err := c.getRaw(ctx, APIEndpoints.NearbyHotspots, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby hotspots: %w", err)
+	}
+
+	return body, nil
+}
+
 func (c *Client) HotspotInfo(ctx context.Context, locId string, opts ...RequestOption) (*HotspotInfo, error) {
 	if locId == "" {
-		return nil, fmt.Errorf("locId cannot be empty")
+		return nil, invalidArgument("locId cannot be empty")
 	}
 
 	endpoint := fmt.Sprintf(APIEndpoints.HotspotInfo, locId)
 	params := processOptions(opts...)
 
 	var hotspot HotspotInfo
-	err := c.get(ctx, endpoint, params.URLParams, &hotspot)
+	err := c.get(ctx, endpoint, params, &hotspot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hotspot info: %w", err)
 	}
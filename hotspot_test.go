@@ -89,11 +89,11 @@ func TestNearbyHotspots(t *testing.T) {
 		{
 			name: "Valid request with lat and lng",
 			input: `[
-				{"locId": "L1670452", "locName": "Vatnajökulsþjóðgarður NP--Skaftafell", "countryCode": "IS", "subnational1Code": "IS-7", "lat": 64.0172413, "lng": -16.9721603, "latestObsDt": "2023-09-24 10:25", "numSpeciesAllTime": 62},
+				{"locId": "L1670452", "locName": "Vatnajökulsþjóðgarður NP--Skaftafell", "countryCode": "IS", "subnational1Code": "IS-7", "subnational2Code": "IS-7-AU", "lat": 64.0172413, "lng": -16.9721603, "latestObsDt": "2023-09-24 10:25", "numSpeciesAllTime": 62},
 				{"locId": "L14359747", "locName": "Öræfi--Fagurhólsmýri", "countryCode": "IS", "subnational1Code": "IS-7", "lat": 63.8781272, "lng": -16.6440526, "latestObsDt": "2023-09-23 15:59", "numSpeciesAllTime": 14}
 			]`,
 			wantResult: []NearbyHotspot{
-				{LocId: "L1670452", LocName: "Vatnajökulsþjóðgarður NP--Skaftafell", CountryCode: "IS", Subnational1Code: "IS-7", Lat: 64.0172413, Lng: -16.9721603, LatestObsDt: "2023-09-24 10:25", NumSpeciesAllTime: 62},
+				{LocId: "L1670452", LocName: "Vatnajökulsþjóðgarður NP--Skaftafell", CountryCode: "IS", Subnational1Code: "IS-7", Subnational2Code: "IS-7-AU", Lat: 64.0172413, Lng: -16.9721603, LatestObsDt: "2023-09-24 10:25", NumSpeciesAllTime: 62},
 				{LocId: "L14359747", LocName: "Öræfi--Fagurhólsmýri", CountryCode: "IS", Subnational1Code: "IS-7", Lat: 63.8781272, Lng: -16.6440526, LatestObsDt: "2023-09-23 15:59", NumSpeciesAllTime: 14},
 			},
 			wantErr: false,
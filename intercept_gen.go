@@ -0,0 +1,295 @@
+// Code generated by internal/genapi; DO NOT EDIT.
+
+package ebird
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// interceptedAPI runs an Interceptor around every call to next.
+type interceptedAPI struct {
+	next API
+	fn   Interceptor
+}
+
+func (a *interceptedAPI) RecentObservationsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "RecentObservationsInRegion", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentObservationsInRegion(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RecentNotableObservationsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "RecentNotableObservationsInRegion", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentNotableObservationsInRegion(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RecentObservationsOfSpeciesInRegion(ctx context.Context, regionCode string, speciesCode string, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "RecentObservationsOfSpeciesInRegion", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentObservationsOfSpeciesInRegion(ctx, regionCode, speciesCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RecentNearbyObservations(ctx context.Context, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "RecentNearbyObservations", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentNearbyObservations(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RecentNearbyObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "RecentNearbyObservationsOfSpecies", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentNearbyObservationsOfSpecies(ctx, speciesCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) NearestObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "NearestObservationsOfSpecies", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.NearestObservationsOfSpecies(ctx, speciesCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RecentNearbyNotableObservations(ctx context.Context, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "RecentNearbyNotableObservations", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentNearbyNotableObservations(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RecentChecklistsFeed(ctx context.Context, regionCode string, opts ...RequestOption) ([]RecentChecklistFeed, error) {
+	var result []RecentChecklistFeed
+	err := a.fn(ctx, "RecentChecklistsFeed", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RecentChecklistsFeed(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) HistoricObservationsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]Observation, error) {
+	var result []Observation
+	err := a.fn(ctx, "HistoricObservationsOnDate", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.HistoricObservationsOnDate(ctx, regionCode, date, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) Top100(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]Top100, error) {
+	var result []Top100
+	err := a.fn(ctx, "Top100", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.Top100(ctx, regionCode, date, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) ChecklistFeedOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]ChecklistFeedOnDate, error) {
+	var result []ChecklistFeedOnDate
+	err := a.fn(ctx, "ChecklistFeedOnDate", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.ChecklistFeedOnDate(ctx, regionCode, date, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RegionalStatisticsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) (*RegionalStatisticsOnDate, error) {
+	var result *RegionalStatisticsOnDate
+	err := a.fn(ctx, "RegionalStatisticsOnDate", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RegionalStatisticsOnDate(ctx, regionCode, date, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) SpeciesListForRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]string, error) {
+	var result []string
+	err := a.fn(ctx, "SpeciesListForRegion", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.SpeciesListForRegion(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) ViewChecklist(ctx context.Context, subId string, opts ...RequestOption) (*ViewChecklist, error) {
+	var result *ViewChecklist
+	err := a.fn(ctx, "ViewChecklist", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.ViewChecklist(ctx, subId, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) HotspotsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]HotspotInRegion, error) {
+	var result []HotspotInRegion
+	err := a.fn(ctx, "HotspotsInRegion", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.HotspotsInRegion(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) NearbyHotspots(ctx context.Context, opts ...RequestOption) ([]NearbyHotspot, error) {
+	var result []NearbyHotspot
+	err := a.fn(ctx, "NearbyHotspots", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.NearbyHotspots(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) HotspotsInRegionCSV(ctx context.Context, regionCode string, opts ...RequestOption) (io.ReadCloser, error) {
+	var result io.ReadCloser
+	err := a.fn(ctx, "HotspotsInRegionCSV", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.HotspotsInRegionCSV(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) NearbyHotspotsCSV(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error) {
+	var result io.ReadCloser
+	err := a.fn(ctx, "NearbyHotspotsCSV", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.NearbyHotspotsCSV(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) HotspotInfo(ctx context.Context, locId string, opts ...RequestOption) (*HotspotInfo, error) {
+	var result *HotspotInfo
+	err := a.fn(ctx, "HotspotInfo", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.HotspotInfo(ctx, locId, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) EbirdTaxonomy(ctx context.Context, opts ...RequestOption) ([]EbirdTaxon, error) {
+	var result []EbirdTaxon
+	err := a.fn(ctx, "EbirdTaxonomy", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.EbirdTaxonomy(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) EbirdTaxonomyCSV(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error) {
+	var result io.ReadCloser
+	err := a.fn(ctx, "EbirdTaxonomyCSV", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.EbirdTaxonomyCSV(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) TaxonomicForms(ctx context.Context, speciesCode string, opts ...RequestOption) ([]string, error) {
+	var result []string
+	err := a.fn(ctx, "TaxonomicForms", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.TaxonomicForms(ctx, speciesCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) TaxonomicGroups(ctx context.Context, speciesGrouping string, opts ...RequestOption) ([]TaxonomicGroup, error) {
+	var result []TaxonomicGroup
+	err := a.fn(ctx, "TaxonomicGroups", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.TaxonomicGroups(ctx, speciesGrouping, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) TaxonomyVersions(ctx context.Context, opts ...RequestOption) ([]TaxonomyVersion, error) {
+	var result []TaxonomyVersion
+	err := a.fn(ctx, "TaxonomyVersions", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.TaxonomyVersions(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) TaxaLocaleCodes(ctx context.Context, opts ...RequestOption) ([]TaxaLocaleCode, error) {
+	var result []TaxaLocaleCode
+	err := a.fn(ctx, "TaxaLocaleCodes", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.TaxaLocaleCodes(ctx, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) RegionInfo(ctx context.Context, regionCode string, opts ...RequestOption) (*RegionInfo, error) {
+	var result *RegionInfo
+	err := a.fn(ctx, "RegionInfo", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.RegionInfo(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) SubRegionList(ctx context.Context, regionType string, parentRegionCode string, opts ...RequestOption) ([]SubRegion, error) {
+	var result []SubRegion
+	err := a.fn(ctx, "SubRegionList", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.SubRegionList(ctx, regionType, parentRegionCode, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (a *interceptedAPI) AdjacentRegions(ctx context.Context, regionCode string, opts ...AdjacentRegionsOption) ([]AdjacentRegion, error) {
+	var result []AdjacentRegion
+	err := a.fn(ctx, "AdjacentRegions", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.AdjacentRegions(ctx, regionCode, opts...)
+		return err
+	})
+	return result, err
+}
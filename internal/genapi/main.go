@@ -0,0 +1,282 @@
+// Command genapi generates the API wrappers from the interfaces in api.go:
+// the Intercept decorator in intercept_gen.go and the mock in
+// ebirdmock/mock.go. Run it with go generate from the repository root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// interfaces are the per-area interfaces that make up API, in order.
+var interfaces = []string{"ObservationsAPI", "ProductAPI", "HotspotAPI", "TaxonomyAPI", "RegionAPI"}
+
+type param struct {
+	Name     string
+	Type     string
+	Variadic bool
+}
+
+type method struct {
+	Name    string
+	Params  []param
+	Results []string
+}
+
+// Signature returns the parameter list, such as
+// "ctx context.Context, opts ...RequestOption".
+func (m method) Signature() string {
+	parts := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		parts[i] = p.Name + " " + p.Type
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Args returns the arguments for forwarding the call, such as "ctx, opts...".
+func (m method) Args() string {
+	parts := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		parts[i] = p.Name
+		if p.Variadic {
+			parts[i] += "..."
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ArgsWithoutCtx returns the arguments after ctx, for recording calls.
+// Variadic arguments are recorded as a slice.
+func (m method) ArgsWithoutCtx() string {
+	parts := make([]string, 0, len(m.Params))
+	for _, p := range m.Params[1:] {
+		parts = append(parts, p.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Result returns the type of the non-error result.
+func (m method) Result() string {
+	return m.Results[0]
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "api.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	local := collect(fset, file, false)
+	qualified := collect(fset, file, true)
+
+	write("intercept_gen.go", interceptTemplate, local)
+	write(filepath.Join("ebirdmock", "mock.go"), mockTemplate, qualified)
+}
+
+// packages maps the package names that may appear in method signatures to
+// their import paths.
+var packages = map[string]string{
+	"context": "context",
+	"io":      "io",
+	"time":    "time",
+}
+
+// imports returns the standard library imports the methods' types need.
+func imports(methods []method) []string {
+	used := map[string]bool{"context": true}
+	for _, m := range methods {
+		types := append([]string(nil), m.Results...)
+		for _, p := range m.Params {
+			types = append(types, p.Type)
+		}
+		for _, t := range types {
+			for name := range packages {
+				if strings.Contains(t, name+".") {
+					used[name] = true
+				}
+			}
+		}
+	}
+
+	var paths []string
+	for name := range used {
+		paths = append(paths, packages[name])
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// collect returns the methods of the interfaces. With qualify set, types
+// declared in package ebird are prefixed with "ebird.".
+func collect(fset *token.FileSet, file *ast.File, qualify bool) []method {
+	decls := make(map[string]*ast.InterfaceType)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				decls[ts.Name.Name] = it
+			}
+		}
+	}
+
+	typeString := func(expr ast.Expr) string {
+		if qualify {
+			expr = qualifyExpr(expr)
+		}
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fset, expr); err != nil {
+			log.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	var methods []method
+	for _, name := range interfaces {
+		it, ok := decls[name]
+		if !ok {
+			log.Fatalf("interface %s not found in api.go", name)
+		}
+		for _, field := range it.Methods.List {
+			fn := field.Type.(*ast.FuncType)
+			m := method{Name: field.Names[0].Name}
+			for _, p := range fn.Params.List {
+				_, variadic := p.Type.(*ast.Ellipsis)
+				for _, n := range p.Names {
+					m.Params = append(m.Params, param{Name: n.Name, Type: typeString(p.Type), Variadic: variadic})
+				}
+			}
+			for _, r := range fn.Results.List {
+				m.Results = append(m.Results, typeString(r.Type))
+			}
+			if len(m.Results) != 2 || m.Results[1] != "error" || len(m.Params) == 0 || m.Params[0].Name != "ctx" {
+				log.Fatalf("%s.%s: methods must take ctx first and return (T, error)", name, m.Name)
+			}
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// qualifyExpr returns a copy of expr with exported identifiers from package
+// ebird qualified.
+func qualifyExpr(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(e.Name[0])) {
+			return &ast.SelectorExpr{X: ast.NewIdent("ebird"), Sel: ast.NewIdent(e.Name)}
+		}
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyExpr(e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: qualifyExpr(e.Elt)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualifyExpr(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualifyExpr(e.Key), Value: qualifyExpr(e.Value)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: qualifyExpr(e.Value)}
+	}
+	return expr
+}
+
+func write(path string, tmpl *template.Template, methods []method) {
+	data := struct {
+		Imports []string
+		Methods []method
+	}{imports(methods), methods}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("%s: %v\n%s", path, err, buf.Bytes())
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("wrote", path)
+}
+
+var interceptTemplate = template.Must(template.New("intercept").Parse(`// Code generated by internal/genapi; DO NOT EDIT.
+
+package ebird
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// interceptedAPI runs an Interceptor around every call to next.
+type interceptedAPI struct {
+	next API
+	fn   Interceptor
+}
+{{range .Methods}}
+func (a *interceptedAPI) {{.Name}}({{.Signature}}) ({{.Result}}, error) {
+	var result {{.Result}}
+	err := a.fn(ctx, "{{.Name}}", func(ctx context.Context) error {
+		var err error
+		result, err = a.next.{{.Name}}({{.Args}})
+		return err
+	})
+	return result, err
+}
+{{end}}`))
+
+var mockTemplate = template.Must(template.New("mock").Parse(`// Code generated by internal/genapi; DO NOT EDIT.
+
+package ebirdmock
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+
+	"github.com/siansiansu/go-ebird"
+)
+
+// Client is a mock ebird.API. Each method calls the matching Func field.
+// Methods whose Func is nil return ErrNotMocked.
+//
+// Calls are recorded whether or not they are mocked, and Client is safe for
+// concurrent use as long as the Func fields are set before the first call.
+type Client struct {
+	calls recorder
+{{range .Methods}}
+	{{.Name}}Func func({{.Signature}}) ({{.Result}}, error)
+{{- end}}
+}
+
+var _ ebird.API = (*Client)(nil)
+{{range .Methods}}
+// {{.Name}} calls {{.Name}}Func.
+func (m *Client) {{.Name}}({{.Signature}}) ({{.Result}}, error) {
+	m.calls.record("{{.Name}}", {{.ArgsWithoutCtx}})
+	if m.{{.Name}}Func == nil {
+		var zero {{.Result}}
+		return zero, notMocked("{{.Name}}")
+	}
+	return m.{{.Name}}Func({{.Args}})
+}
+{{end}}`))
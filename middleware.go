@@ -0,0 +1,135 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Request describes an API call as seen by middleware.
+type Request struct {
+	// Endpoint is the APIEndpoints field name, such as
+	// "RecentObservationsInRegion", or "" for paths passed to Raw that match
+	// no endpoint.
+	Endpoint string
+	// URL is the resolved request URL, including the query. It is what the
+	// client sends, so middleware may change it.
+	URL *url.URL
+	// Params is a copy of the query parameters, for inspection.
+	Params url.Values
+	// Header holds extra headers to send with each attempt. The client's
+	// own headers, such as X-eBirdApiToken, take precedence, except that an
+	// Accept-Language set here overrides WithAcceptLanguage.
+	Header http.Header
+}
+
+// Response describes the outcome of an API call as seen by middleware.
+type Response struct {
+	// StatusCode is the final HTTP status, or 0 if no response was received.
+	StatusCode int
+	Header     http.Header
+	// Body is the response body. It is nil for the streaming CSV methods,
+	// whose body is read by the caller after the call returns.
+	Body []byte
+	// BytesRead is the size of Body, or -1 for streamed responses.
+	BytesRead int64
+	// Latency is the time spent in the call, including rate limiting and
+	// retries.
+	Latency time.Duration
+	// Attempts is the number of HTTP requests made. It is 0 for cache hits.
+	Attempts int
+	// CacheHit is set when the body came from the client's cache.
+	CacheHit bool
+
+	stream io.ReadCloser
+}
+
+// Handler makes an API call. Handlers always return a non-nil Response,
+// together with the error if the call failed.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps every API call made by a Client. It can inspect or change
+// the request before calling next, inspect the response afterwards, or
+// return its own response or error without calling next:
+//
+//	logging := func(next ebird.Handler) ebird.Handler {
+//		return func(ctx context.Context, req *ebird.Request) (*ebird.Response, error) {
+//			resp, err := next(ctx, req)
+//			log.Printf("%s %d %v %dB", req.Endpoint, resp.StatusCode, resp.Latency, resp.BytesRead)
+//			return resp, err
+//		}
+//	}
+//
+// Middleware runs around the cache, rate limiter and retries, so it sees one
+// call per client method invocation.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to the client. The first middleware is the
+// outermost, so it sees each request first and each response last.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.middleware = append(client.middleware, middleware...)
+	}
+}
+
+// roundTrip runs the middleware chain around handler.
+func (c *Client) roundTrip(ctx context.Context, name string, u *url.URL, params RequestOptions, handler Handler) (*Response, error) {
+	req := &Request{
+		Endpoint: name,
+		URL:      u,
+		Params:   u.Query(),
+		Header:   make(http.Header),
+	}
+	if params.acceptLanguage != "" {
+		req.Header.Set("Accept-Language", params.acceptLanguage)
+	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+
+	resp, err := handler(ctx, req)
+	if resp == nil {
+		resp = &Response{}
+	}
+	return resp, err
+}
+
+// send makes the HTTP request for req. With stream set the body is left for
+// the caller to read.
+func (c *Client) send(ctx context.Context, req *Request, stream bool) (*Response, error) {
+	start := time.Now()
+	httpResp, attempts, err := c.do(ctx, req.Endpoint, req.URL, req.Header)
+	resp := &Response{Attempts: attempts}
+	if err != nil {
+		resp.Latency = time.Since(start)
+		var apiErr Error
+		if errors.As(err, &apiErr) {
+			resp.StatusCode = apiErr.Status
+		}
+		return resp, err
+	}
+
+	resp.StatusCode = httpResp.StatusCode
+	resp.Header = httpResp.Header
+	if stream {
+		resp.stream = httpResp.Body
+		resp.BytesRead = -1
+		resp.Latency = time.Since(start)
+		return resp, nil
+	}
+
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	resp.Latency = time.Since(start)
+	resp.BytesRead = int64(len(body))
+	if err != nil {
+		return resp, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = body
+	return resp, nil
+}
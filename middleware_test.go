@@ -0,0 +1,222 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareSeesCall(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "tests", r.Header.Get("X-Client"))
+		w.Write([]byte(`[{"speciesCode":"amecro"}]`))
+	}))
+	defer server.Close()
+
+	var seen []*Request
+	var responses []*Response
+	record := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("X-Client", "tests")
+			resp, err := next(ctx, req)
+			seen = append(seen, req)
+			responses = append(responses, resp)
+			return resp, err
+		}
+	}
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+		WithMiddleware(record),
+	)
+	require.NoError(t, err)
+
+	_, err = client.RecentObservationsInRegion(context.Background(), "US-NY", Back(5))
+	require.NoError(t, err)
+
+	require.Len(t, seen, 1)
+	assert.Equal(t, "RecentObservationsInRegion", seen[0].Endpoint)
+	assert.Equal(t, "/data/obs/US-NY/recent", seen[0].URL.Path)
+	assert.Equal(t, "5", seen[0].Params.Get("back"))
+
+	resp := responses[0]
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(len(`[{"speciesCode":"amecro"}]`)), resp.BytesRead)
+	assert.Equal(t, 2, resp.Attempts)
+	assert.Positive(t, resp.Latency)
+	assert.False(t, resp.CacheHit)
+}
+
+func TestMiddlewareOrderAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"title":"Not found"}]}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var order []string
+	var status int
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name)
+				resp, err := next(ctx, req)
+				status = resp.StatusCode
+				return resp, err
+			}
+		}
+	}
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithMiddleware(trace("outer"), trace("inner")))
+	require.NoError(t, err)
+
+	_, err = client.HotspotInfo(context.Background(), "L123")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	errInjected := errors.New("injected")
+	inject := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			switch req.Endpoint {
+			case "TaxonomyVersions":
+				return &Response{}, errInjected
+			case "TaxaLocaleCodes":
+				return &Response{StatusCode: http.StatusOK, Body: []byte(`[{"code":"fr","name":"French"}]`)}, nil
+			}
+			return next(ctx, req)
+		}
+	}
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithMiddleware(inject), WithCache(NewMemoryCache(10)))
+	require.NoError(t, err)
+
+	_, err = client.TaxonomyVersions(context.Background())
+	assert.ErrorIs(t, err, errInjected)
+
+	locales, err := client.TaxaLocaleCodes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, locales, 1)
+	assert.Equal(t, "fr", locales[0].Code)
+	assert.Zero(t, hits.Load())
+}
+
+func TestMiddlewareCacheHitsAndStreams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("SPECIES_CODE\namecro\n"))
+	}))
+	defer server.Close()
+
+	var responses []*Response
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/"),
+		WithCache(NewMemoryCache(10)),
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				resp, err := next(ctx, req)
+				responses = append(responses, resp)
+				return resp, err
+			}
+		}),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.EbirdTaxonomy(context.Background(), Fmt("csv"))
+		require.NoError(t, err)
+	}
+	body, err := client.EbirdTaxonomyCSV(context.Background())
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	body.Close()
+	assert.Equal(t, "SPECIES_CODE\namecro\n", string(data))
+
+	require.Len(t, responses, 3)
+	assert.False(t, responses[0].CacheHit)
+	assert.True(t, responses[1].CacheHit)
+	assert.Zero(t, responses[1].Attempts)
+	assert.Equal(t, int64(-1), responses[2].BytesRead)
+}
+
+func TestMiddlewareCannotCorruptCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"code":"fr","name":"French"}]`))
+	}))
+	defer server.Close()
+
+	scribble := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			if err == nil && resp.CacheHit {
+				for i := range resp.Body {
+					resp.Body[i] = 'x'
+				}
+			}
+			return resp, err
+		}
+	}
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithCache(NewMemoryCache(10)), WithMiddleware(scribble))
+	require.NoError(t, err)
+
+	body, err := client.Raw(context.Background(), "ref/taxa-locales/ebird")
+	require.NoError(t, err)
+	for i := range body {
+		body[i] = 'x'
+	}
+
+	_, err = client.Raw(context.Background(), "ref/taxa-locales/ebird")
+	require.NoError(t, err)
+
+	client.middleware = nil
+	body, err = client.Raw(context.Background(), "ref/taxa-locales/ebird")
+	require.NoError(t, err)
+	assert.Equal(t, `[{"code":"fr","name":"French"}]`, string(body))
+}
+
+func TestMiddlewareRawUnknownEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var endpoints []string
+	record := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			endpoints = append(endpoints, req.Endpoint)
+			return next(ctx, req)
+		}
+	}
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithMiddleware(record))
+	require.NoError(t, err)
+
+	_, err = client.Raw(context.Background(), "foo/bar/US-NY")
+	require.NoError(t, err)
+	_, err = client.Raw(context.Background(), "/data/obs/US-NY/recent")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "RecentObservationsInRegion"}, endpoints)
+}
@@ -0,0 +1,156 @@
+package ebird
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Notifier delivers the alerts found by one poll of a Watcher.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []Alert) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, alerts []Alert) error
+
+func (f NotifierFunc) Notify(ctx context.Context, alerts []Alert) error {
+	return f(ctx, alerts)
+}
+
+// WriterNotifier writes one line per alert, such as to os.Stdout.
+type WriterNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{w: w}
+}
+
+func (n *WriterNotifier) Notify(ctx context.Context, alerts []Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, a := range alerts {
+		if _, err := fmt.Fprintln(n.w, a.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WebhookNotifier posts the alerts of each poll as a JSON object of the form
+// {"alerts": [...]} to a URL. Any response other than 2xx is an error.
+type WebhookNotifier struct {
+	URL        string
+	Header     http.Header
+	HTTPClient *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:        url,
+		Header:     http.Header{},
+		HTTPClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alerts []Alert) error {
+	body, err := json.Marshal(struct {
+		Alerts []Alert `json:"alerts"`
+	}{alerts})
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for name, values := range n.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := n.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTPNotifier emails the alerts of each poll as one plain text message.
+type SMTPNotifier struct {
+	Addr    string
+	Auth    smtp.Auth
+	From    string
+	To      []string
+	Subject string
+
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+// NewSMTPNotifier returns a notifier that sends mail through the server at
+// addr, such as "smtp.example.com:587". auth may be nil.
+func NewSMTPNotifier(addr string, auth smtp.Auth, from string, to ...string) *SMTPNotifier {
+	return &SMTPNotifier{
+		Addr:     addr,
+		Auth:     auth,
+		From:     from,
+		To:       to,
+		Subject:  "eBird rare bird alert",
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, alerts []Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	sendMail, now := n.sendMail, n.now
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s (%d)\r\n", n.Subject, len(alerts))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	for _, a := range alerts {
+		msg.WriteString(a.String())
+		msg.WriteString("\r\n")
+	}
+
+	if err := sendMail(n.Addr, n.Auth, n.From, n.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
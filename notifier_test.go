@@ -0,0 +1,101 @@
+package ebird
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAlerts = []Alert{
+	{Observation: Observation{ComName: "Snowy Owl", SpeciesCode: "snoowl1", LocName: "Jones Beach", ObsDt: "2024-01-05 10:12", SubId: "S1"}, Target: WatchTarget{RegionCode: "US-NY"}},
+	{Observation: Observation{ComName: "Gyrfalcon", SpeciesCode: "gyrfal", LocName: "Montezuma", ObsDt: "2024-01-05 11:00", SubId: "S2"}, Target: WatchTarget{RegionCode: "US-NY"}},
+}
+
+func TestWriterNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewWriterNotifier(&buf).Notify(context.Background(), testAlerts))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "Snowy Owl at Jones Beach"))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var got struct {
+		Alerts []Alert `json:"alerts"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL)
+	n.Header.Set("X-Token", "secret")
+	require.NoError(t, n.Notify(context.Background(), testAlerts))
+	require.Len(t, got.Alerts, 2)
+	assert.Equal(t, "gyrfal", got.Alerts[1].SpeciesCode)
+	assert.Equal(t, "US-NY", got.Alerts[1].Target.RegionCode)
+
+	failing := NewWebhookNotifier(server.URL + "/missing")
+	failing.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+	})}
+	assert.EqualError(t, failing.Notify(context.Background(), testAlerts), "webhook returned HTTP 500")
+
+	literal := &WebhookNotifier{URL: server.URL, Header: http.Header{"X-Token": {"secret"}}}
+	require.NoError(t, literal.Notify(context.Background(), testAlerts))
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+
+	n := NewSMTPNotifier("smtp.example.com:587", nil, "alerts@example.com", "a@example.com", "b@example.com")
+	n.now = func() time.Time { return time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC) }
+	n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		return nil
+	}
+
+	require.NoError(t, n.Notify(context.Background(), testAlerts))
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, "alerts@example.com", gotFrom)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, gotTo)
+
+	msg := string(gotMsg)
+	assert.Contains(t, msg, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, msg, "Subject: eBird rare bird alert (2)\r\n")
+	assert.Contains(t, msg, "\r\n\r\nSnowy Owl at Jones Beach")
+	assert.Contains(t, msg, "https://ebird.org/checklist/S2\r\n")
+}
+
+func TestSMTPNotifierZeroValue(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	n := &SMTPNotifier{Addr: addr, From: "alerts@example.com", To: []string{"a@example.com"}}
+	assert.ErrorContains(t, n.Notify(context.Background(), testAlerts), "failed to send email")
+}
@@ -21,6 +21,13 @@ type Observation struct {
 	LocationPrivate bool    `json:"locationPrivate,omitempty"`
 	SubId           string  `json:"subId,omitempty"`
 	ExoticCategory  string  `json:"exoticCategory,omitempty"`
+	// The region codes are only included in detailed responses.
+	CountryCode      string `json:"countryCode,omitempty"`
+	Subnational1Code string `json:"subnational1Code,omitempty"`
+	Subnational2Code string `json:"subnational2Code,omitempty"`
+
+	// ObsDtParsed is filled from ObsDt when the client uses WithParsedTimes.
+	ObsDtParsed time.Time `json:"-"`
 }
 
 type Location struct {
@@ -52,58 +59,77 @@ type RecentChecklistFeed struct {
 	IsoObsDate      string   `json:"isoObsDate,omitempty"`
 	SubID           string   `json:"subID,omitempty"`
 	Loc             Location `json:"loc,omitempty"`
+
+	ObsDtParsed time.Time `json:"-"`
 }
 
 func (c *Client) RecentObservationsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]Observation, error) {
-	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentObservationsInRegion, regionCode), opts...)
+	regionCode, err := checkObservationRegion(regionCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentObservationsInRegion, regionCode), regionCode, opts...)
 }
 
 func (c *Client) RecentNotableObservationsInRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]Observation, error) {
-	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentNotableObservationsInRegion, regionCode), opts...)
+	regionCode, err := checkObservationRegion(regionCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentNotableObservationsInRegion, regionCode), regionCode, opts...)
 }
 
 func (c *Client) RecentObservationsOfSpeciesInRegion(ctx context.Context, regionCode, speciesCode string, opts ...RequestOption) ([]Observation, error) {
+	regionCode, err := checkObservationRegion(regionCode)
+	if err != nil {
+		return nil, err
+	}
 	if speciesCode == "" {
-		return nil, fmt.Errorf("speciesCode cannot be empty")
+		return nil, invalidArgument("speciesCode cannot be empty")
 	}
-	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentObservationsOfSpeciesInRegion, regionCode, speciesCode), opts...)
+	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentObservationsOfSpeciesInRegion, regionCode, speciesCode), regionCode, opts...)
 }
 
 func (c *Client) RecentNearbyObservations(ctx context.Context, opts ...RequestOption) ([]Observation, error) {
 	params := processOptions(opts...)
 	if params.URLParams.Get("lat") == "" || params.URLParams.Get("lng") == "" {
-		return nil, fmt.Errorf("must provide Lat and Lng parameters")
+		return nil, invalidArgument("must provide Lat and Lng parameters")
 	}
-	return c.getObservations(ctx, APIEndpoints.RecentNearbyObservations, opts...)
+	return c.getObservations(ctx, APIEndpoints.RecentNearbyObservations, "", opts...)
 }
 
 func (c *Client) RecentNearbyObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...RequestOption) ([]Observation, error) {
 	if speciesCode == "" {
-		return nil, fmt.Errorf("speciesCode cannot be empty")
+		return nil, invalidArgument("speciesCode cannot be empty")
 	}
-	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentNearbyObservationsOfSpecies, speciesCode), opts...)
+	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.RecentNearbyObservationsOfSpecies, speciesCode), "", opts...)
 }
 
 func (c *Client) NearestObservationsOfSpecies(ctx context.Context, speciesCode string, opts ...RequestOption) ([]Observation, error) {
 	if speciesCode == "" {
-		return nil, fmt.Errorf("speciesCode cannot be empty")
+		return nil, invalidArgument("speciesCode cannot be empty")
 	}
-	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.NearestObservationsOfSpecies, speciesCode), opts...)
+	return c.getObservations(ctx, fmt.Sprintf(APIEndpoints.NearestObservationsOfSpecies, speciesCode), "", opts...)
 }
 
 func (c *Client) RecentNearbyNotableObservations(ctx context.Context, opts ...RequestOption) ([]Observation, error) {
-	return c.getObservations(ctx, APIEndpoints.RecentNearbyNotableObservations, opts...)
+	return c.getObservations(ctx, APIEndpoints.RecentNearbyNotableObservations, "", opts...)
 }
 
 func (c *Client) RecentChecklistsFeed(ctx context.Context, regionCode string, opts ...RequestOption) ([]RecentChecklistFeed, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
 	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent checklists feed: %w", err)
+	}
+	regionCode = code.String()
 	endpoint := fmt.Sprintf(APIEndpoints.RecentChecklistsFeed, regionCode)
 	params := processOptions(opts...)
 
 	var checklists []RecentChecklistFeed
-	err := c.get(ctx, endpoint, params.URLParams, &checklists)
+	err = c.get(ctx, endpoint, params, &checklists)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent checklists feed: %w", err)
 	}
@@ -111,17 +137,34 @@ func (c *Client) RecentChecklistsFeed(ctx context.Context, regionCode string, op
 }
 
 func (c *Client) HistoricObservationsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]Observation, error) {
-	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+	regionCode, err := checkObservationRegion(regionCode)
+	if err != nil {
+		return nil, err
 	}
 	endpoint := fmt.Sprintf(APIEndpoints.HistoricObservationsOnDate, regionCode, date.Year(), date.Month(), date.Day())
-	return c.getObservations(ctx, endpoint, opts...)
+	return c.getObservations(ctx, endpoint, regionCode, opts...)
+}
+
+// checkObservationRegion validates regionCode and returns it in canonical
+// form.
+func checkObservationRegion(regionCode string) (string, error) {
+	if regionCode == "" {
+		return "", invalidArgument("regionCode cannot be empty")
+	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return "", fmt.Errorf("failed to get observations: %w", err)
+	}
+	return code.String(), nil
 }
 
-func (c *Client) getObservations(ctx context.Context, endpoint string, opts ...RequestOption) ([]Observation, error) {
+// getObservations fetches observations from endpoint. regionCode is the
+// region requested, if any, and is used to parse observation times.
+func (c *Client) getObservations(ctx context.Context, endpoint, regionCode string, opts ...RequestOption) ([]Observation, error) {
 	params := processOptions(opts...)
+	params.regionCode = regionCode
 	var observations []Observation
-	err := c.get(ctx, endpoint, params.URLParams, &observations)
+	err := c.get(ctx, endpoint, params, &observations)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get observations: %w", err)
 	}
@@ -1,6 +1,7 @@
 package ebird
 
 import (
+	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
@@ -11,7 +12,14 @@ type ClientOption func(*Client)
 type RequestOption func(*RequestOptions)
 
 type RequestOptions struct {
-	URLParams url.Values
+	URLParams   url.Values
+	cacheMode   cacheMode
+	strict      bool
+	concurrency int
+	errs        []error
+	// regionCode is the region of the request, used to parse times.
+	regionCode     string
+	acceptLanguage string
 }
 
 func processOptions(options ...RequestOption) RequestOptions {
@@ -28,12 +36,19 @@ func Back(days int) RequestOption {
 	return func(o *RequestOptions) {
 		if days > 0 && days <= 30 {
 			o.URLParams.Set("back", strconv.Itoa(days))
+		} else {
+			o.invalid("Back", days, "must be between 1 and 30")
 		}
 	}
 }
 
 func Cat(category string) RequestOption {
 	return func(o *RequestOptions) {
+		for _, c := range strings.Split(category, ",") {
+			if !contains(validCategories, c) {
+				o.invalid("Cat", category, fmt.Sprintf("unknown category %q", c))
+			}
+		}
 		o.URLParams.Set("cat", category)
 	}
 }
@@ -48,6 +63,8 @@ func Dist(distance int) RequestOption {
 	return func(o *RequestOptions) {
 		if distance >= 0 && distance <= 500 {
 			o.URLParams.Set("dist", strconv.Itoa(distance))
+		} else {
+			o.invalid("Dist", distance, "must be between 0 and 500")
 		}
 	}
 }
@@ -56,6 +73,8 @@ func Fmt(format string) RequestOption {
 	return func(o *RequestOptions) {
 		if format == "csv" || format == "json" {
 			o.URLParams.Set("fmt", format)
+		} else {
+			o.invalid("Fmt", format, `must be "csv" or "json"`)
 		}
 	}
 }
@@ -82,6 +101,8 @@ func Lat(latitude float64) RequestOption {
 	return func(o *RequestOptions) {
 		if latitude >= -90 && latitude <= 90 {
 			o.URLParams.Set("lat", strconv.FormatFloat(latitude, 'f', 2, 64))
+		} else {
+			o.invalid("Lat", latitude, "must be between -90 and 90")
 		}
 	}
 }
@@ -90,6 +111,8 @@ func Lng(longitude float64) RequestOption {
 	return func(o *RequestOptions) {
 		if longitude >= -180 && longitude <= 180 {
 			o.URLParams.Set("lng", strconv.FormatFloat(longitude, 'f', 2, 64))
+		} else {
+			o.invalid("Lng", longitude, "must be between -180 and 180")
 		}
 	}
 }
@@ -104,6 +127,8 @@ func MaxResults(max int) RequestOption {
 	return func(o *RequestOptions) {
 		if max > 0 && max <= 100 {
 			o.URLParams.Set("maxResults", strconv.Itoa(max))
+		} else {
+			o.invalid("MaxResults", max, "must be between 1 and 100")
 		}
 	}
 }
@@ -112,6 +137,8 @@ func R(locations ...string) RequestOption {
 	return func(o *RequestOptions) {
 		if len(locations) > 0 && len(locations) <= 10 {
 			o.URLParams.Set("r", strings.Join(locations, ","))
+		} else {
+			o.invalid("R", locations, fmt.Sprintf("takes 1 to 10 locations, got %d", len(locations)))
 		}
 	}
 }
@@ -120,6 +147,8 @@ func RankedBy(rankMethod string) RequestOption {
 	return func(o *RequestOptions) {
 		if rankMethod == "spp" || rankMethod == "cl" {
 			o.URLParams.Set("rankedBy", rankMethod)
+		} else {
+			o.invalid("RankedBy", rankMethod, `must be "spp" or "cl"`)
 		}
 	}
 }
@@ -129,6 +158,8 @@ func RegionNameFormat(format string) RequestOption {
 		validFormats := []string{"detailed", "detailednoqual", "full", "namequal", "nameonly", "revdetailed"}
 		if contains(validFormats, format) {
 			o.URLParams.Set("regionNameFormat", format)
+		} else {
+			o.invalid("RegionNameFormat", format, "must be one of "+strings.Join(validFormats, ", "))
 		}
 	}
 }
@@ -137,6 +168,8 @@ func SortKey(key string) RequestOption {
 	return func(o *RequestOptions) {
 		if key == "obs_dt" || key == "creation_dt" {
 			o.URLParams.Set("sortKey", key)
+		} else {
+			o.invalid("SortKey", key, `must be "obs_dt" or "creation_dt"`)
 		}
 	}
 }
@@ -145,6 +178,8 @@ func Species(speciesCodes ...string) RequestOption {
 	return func(o *RequestOptions) {
 		if len(speciesCodes) > 0 {
 			o.URLParams.Set("species", strings.Join(speciesCodes, ","))
+		} else {
+			o.invalid("Species", speciesCodes, "takes at least one species code")
 		}
 	}
 }
@@ -161,6 +196,54 @@ func Version(version string) RequestOption {
 	}
 }
 
+// Params adds raw URL parameters to the request, replacing any earlier
+// values for the same names. It is meant for forwarding query strings; the
+// values are not checked.
+func Params(values url.Values) RequestOption {
+	return func(o *RequestOptions) {
+		for name, v := range values {
+			o.URLParams[name] = append([]string(nil), v...)
+		}
+	}
+}
+
+// AcceptLanguage sets the Accept-Language header for this request,
+// overriding WithAcceptLanguage.
+func AcceptLanguage(lang string) RequestOption {
+	return func(o *RequestOptions) {
+		o.acceptLanguage = lang
+	}
+}
+
+// Strict makes this request fail with an *OptionError if any option is out
+// of range or not supported by the endpoint, instead of silently dropping it.
+func Strict() RequestOption {
+	return func(o *RequestOptions) {
+		o.strict = true
+	}
+}
+
+// NoCache makes the request skip the client's cache entirely.
+func NoCache() RequestOption {
+	return func(o *RequestOptions) {
+		o.cacheMode = cacheBypass
+	}
+}
+
+// RefreshCache makes the request ignore any cached response and store the
+// fresh one.
+func RefreshCache() RequestOption {
+	return func(o *RequestOptions) {
+		o.cacheMode = cacheRefresh
+	}
+}
+
+func defaultFormat(o RequestOptions, format string) {
+	if o.URLParams.Get("fmt") == "" {
+		o.URLParams.Set("fmt", format)
+	}
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -0,0 +1,394 @@
+// Package otelebird instruments an ebird.Client with OpenTelemetry.
+//
+// Middleware returns an ebird.Middleware that starts a client span for every
+// API call and records metrics:
+//
+//	client, err := ebird.NewClient(key, ebird.WithMiddleware(otelebird.Middleware()))
+//
+// Spans use the attributes of the OpenTelemetry semantic conventions for
+// HTTP clients, with the eBird endpoint name, region code and species code
+// added. The API key is never recorded.
+//
+// Unlike the conventions, which describe one span per HTTP request, the
+// middleware sees a whole client call. Its span covers the cache lookup,
+// rate limiter waits and every retry, and http.request.resend_count reports
+// how many times the request was repeated. For the same reason
+// http.client.request.duration is recorded once per call that reached the
+// API, and the duration of every call, cache hits included, is recorded as
+// ebird.client.call.duration.
+//
+// The package is a separate module so that the ebird package itself does not
+// depend on OpenTelemetry.
+package otelebird
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/siansiansu/go-ebird/otelebird"
+
+// Attribute keys added to spans and metrics on top of the HTTP conventions.
+const (
+	EndpointKey    = attribute.Key("ebird.endpoint")
+	RegionCodeKey  = attribute.Key("ebird.region_code")
+	SpeciesCodeKey = attribute.Key("ebird.species_code")
+	CacheHitKey    = attribute.Key("ebird.cache_hit")
+	ErrorClassKey  = attribute.Key("ebird.error_class")
+)
+
+const redacted = "REDACTED"
+
+// urlTemplateKey is the url.template attribute, which the semconv package
+// used here does not define yet.
+const urlTemplateKey = attribute.Key("url.template")
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. It defaults to the global
+// provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider. It defaults to the global
+// provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators used to inject the trace context into
+// request headers. It defaults to the global propagators.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+type instrumentation struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+
+	duration     metric.Float64Histogram
+	callDuration metric.Float64Histogram
+	errors       metric.Int64Counter
+	retries      metric.Int64Counter
+	cacheHits    metric.Int64Counter
+}
+
+// Middleware returns middleware that traces and measures every API call.
+// It records:
+//
+//   - a client span per call, named after the HTTP method and route, such as
+//     "GET data/obs/{regionCode}/recent"
+//   - http.client.request.duration, the time in seconds spent on the HTTP
+//     requests of calls that reached the API, including retries
+//   - ebird.client.call.duration, the time in seconds of every call,
+//     including cache hits and middleware
+//   - ebird.client.errors, failed calls by ebird.error_class
+//   - ebird.client.retries, requests repeated by the client's RetryPolicy
+//   - ebird.client.cache_hits, calls answered from the client's cache
+func Middleware(opts ...Option) ebird.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName)
+	in := &instrumentation{
+		tracer:      cfg.tracerProvider.Tracer(ScopeName),
+		propagators: cfg.propagators,
+	}
+
+	buckets := metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10)
+	var err error
+	if in.duration, err = meter.Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of HTTP requests to the eBird API."),
+		metric.WithUnit("s"),
+		buckets,
+	); err != nil {
+		otel.Handle(err)
+	}
+	if in.callDuration, err = meter.Float64Histogram("ebird.client.call.duration",
+		metric.WithDescription("Duration of eBird client calls, including cache hits."),
+		metric.WithUnit("s"),
+		buckets,
+	); err != nil {
+		otel.Handle(err)
+	}
+	if in.errors, err = meter.Int64Counter("ebird.client.errors",
+		metric.WithDescription("Failed eBird API calls."),
+		metric.WithUnit("{error}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if in.retries, err = meter.Int64Counter("ebird.client.retries",
+		metric.WithDescription("Requests retried by the client."),
+		metric.WithUnit("{retry}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if in.cacheHits, err = meter.Int64Counter("ebird.client.cache_hits",
+		metric.WithDescription("eBird API calls answered from the client cache."),
+		metric.WithUnit("{hit}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return in.middleware
+}
+
+func (in *instrumentation) middleware(next ebird.Handler) ebird.Handler {
+	return func(ctx context.Context, req *ebird.Request) (*ebird.Response, error) {
+		start := time.Now()
+
+		route := routes[req.Endpoint]
+		name := "GET"
+		if route != "" {
+			name += " " + route
+		}
+
+		common := serverAttributes(req.URL)
+		common = append(common, semconv.HTTPRequestMethodGet)
+		if req.Endpoint != "" {
+			common = append(common, EndpointKey.String(req.Endpoint))
+		}
+
+		if route != "" {
+			common = append(common, urlTemplateKey.String(route))
+		}
+
+		spanAttrs := append([]attribute.KeyValue{semconv.URLFull(redactURL(req.URL))}, common...)
+		spanAttrs = append(spanAttrs, codeAttributes(route, req)...)
+
+		ctx, span := in.tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(spanAttrs...),
+		)
+		defer span.End()
+
+		in.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		resp, err := next(ctx, req)
+
+		attrs := append([]attribute.KeyValue(nil), common...)
+		if resp != nil && resp.StatusCode > 0 {
+			attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+		}
+		if err != nil {
+			attrs = append(attrs, semconv.ErrorTypeKey.String(errorType(resp, err)))
+		}
+		span.SetAttributes(attrs[len(common):]...)
+
+		if resp != nil {
+			span.SetAttributes(CacheHitKey.Bool(resp.CacheHit))
+			if resp.Attempts > 1 {
+				span.SetAttributes(semconv.HTTPRequestResendCount(resp.Attempts - 1))
+			}
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		endpointAttr := metric.WithAttributes(EndpointKey.String(req.Endpoint))
+		// Cache hits and calls answered by other middleware made no request.
+		if resp != nil && resp.Attempts > 0 && in.duration != nil {
+			in.duration.Record(ctx, resp.Latency.Seconds(), metric.WithAttributes(attrs...))
+		}
+		if in.callDuration != nil {
+			callAttrs := append(attrs, CacheHitKey.Bool(resp != nil && resp.CacheHit))
+			in.callDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(callAttrs...))
+		}
+		if err != nil && in.errors != nil {
+			in.errors.Add(ctx, 1, metric.WithAttributes(EndpointKey.String(req.Endpoint), ErrorClassKey.String(errorClass(err))))
+		}
+		if resp != nil && resp.Attempts > 1 && in.retries != nil {
+			in.retries.Add(ctx, int64(resp.Attempts-1), endpointAttr)
+		}
+		if resp != nil && resp.CacheHit && in.cacheHits != nil {
+			in.cacheHits.Add(ctx, 1, endpointAttr)
+		}
+
+		return resp, err
+	}
+}
+
+// serverAttributes returns server.address and server.port for u.
+func serverAttributes(u *url.URL) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.ServerAddress(u.Hostname())}
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		}
+	}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(p))
+	}
+	return attrs
+}
+
+// codeAttributes returns the region and species codes of the request, taken
+// from the path placeholders of the route or the species parameter.
+func codeAttributes(route string, req *ebird.Request) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for name, value := range pathValues(route, req.URL.Path) {
+		switch name {
+		case "regionCode", "parentRegionCode":
+			attrs = append(attrs, RegionCodeKey.String(value))
+		case "speciesCode":
+			attrs = append(attrs, SpeciesCodeKey.String(value))
+		}
+	}
+	if species := req.Params.Get("species"); species != "" && !hasKey(attrs, SpeciesCodeKey) {
+		attrs = append(attrs, SpeciesCodeKey.String(species))
+	}
+	return attrs
+}
+
+func hasKey(attrs []attribute.KeyValue, key attribute.Key) bool {
+	for _, a := range attrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// pathValues matches the placeholders of route against the end of path,
+// which may carry the base URL's path in front.
+func pathValues(route, path string) map[string]string {
+	if route == "" {
+		return nil
+	}
+	routeSegs := strings.Split(route, "/")
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathSegs) < len(routeSegs) {
+		return nil
+	}
+	pathSegs = pathSegs[len(pathSegs)-len(routeSegs):]
+
+	values := make(map[string]string)
+	for i, seg := range routeSegs {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			values[seg[1:len(seg)-1]] = pathSegs[i]
+		}
+	}
+	return values
+}
+
+// errorType returns the error.type attribute: the status code for HTTP
+// errors, or the error class otherwise.
+func errorType(resp *ebird.Response, err error) string {
+	if resp != nil && resp.StatusCode >= 400 {
+		return strconv.Itoa(resp.StatusCode)
+	}
+	return errorClass(err)
+}
+
+// errorClass groups errors into a small set of values for the error counter.
+func errorClass(err error) string {
+	switch {
+	case errors.Is(err, ebird.ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ebird.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ebird.ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ebird.ErrServer):
+		return "server"
+	case errors.Is(err, ebird.ErrInvalidArgument):
+		return "invalid_argument"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	var apiErr ebird.Error
+	if errors.As(err, &apiErr) {
+		return "client"
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return "network"
+	}
+	return semconv.ErrorTypeOther.Value.AsString()
+}
+
+// redactURL removes credentials from u: the password of any user info and
+// the value of a key query parameter.
+func redactURL(u *url.URL) string {
+	r := *u
+	if r.User != nil {
+		if _, ok := r.User.Password(); ok {
+			r.User = url.UserPassword(r.User.Username(), redacted)
+		}
+	}
+	if q := r.Query(); q.Has("key") {
+		q.Set("key", redacted)
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
+
+// routes maps endpoint names to their paths with named placeholders. It is
+// kept in step with ebird.APIEndpoints by the tests.
+var routes = map[string]string{
+	"RecentObservationsInRegion":          "data/obs/{regionCode}/recent",
+	"RecentNotableObservationsInRegion":   "data/obs/{regionCode}/recent/notable",
+	"RecentObservationsOfSpeciesInRegion": "data/obs/{regionCode}/recent/{speciesCode}",
+	"RecentNearbyObservations":            "data/obs/geo/recent",
+	"RecentNearbyObservationsOfSpecies":   "data/obs/geo/recent/{speciesCode}",
+	"NearestObservationsOfSpecies":        "data/nearest/geo/recent/{speciesCode}",
+	"RecentNearbyNotableObservations":     "data/obs/geo/recent/notable",
+	"RecentChecklistsFeed":                "product/lists/{regionCode}",
+	"HistoricObservationsOnDate":          "data/obs/{regionCode}/historic/{y}/{m}/{d}",
+	"ChecklistFeedOnDate":                 "product/lists/{regionCode}/{y}/{m}/{d}",
+	"RegionalStatisticsOnDate":            "product/stats/{regionCode}/{y}/{m}/{d}",
+	"SpeciesListForRegion":                "product/spplist/{regionCode}",
+	"Top100":                              "product/top100/{regionCode}/{y}/{m}/{d}",
+	"ViewChecklist":                       "product/checklist/view/{subId}",
+	"AdjacentRegions":                     "ref/adjacent/{regionCode}",
+	"HotspotInfo":                         "ref/hotspot/info/{locId}",
+	"HotspotsInRegion":                    "ref/hotspot/{regionCode}",
+	"NearbyHotspots":                      "ref/hotspot/geo",
+	"EbirdTaxonomy":                       "ref/taxonomy/ebird",
+	"TaxaLocaleCodes":                     "ref/taxa-locales/ebird",
+	"TaxonomicForms":                      "ref/taxon/forms/{speciesCode}",
+	"TaxonomicGroups":                     "ref/sppgroup/{speciesGrouping}",
+	"TaxonomyVersions":                    "ref/taxonomy/versions",
+	"RegionInfo":                          "ref/region/info/{regionCode}",
+	"SubRegionInfo":                       "ref/region/list/{regionType}/{parentRegionCode}",
+}
@@ -52,6 +52,10 @@ type ViewChecklist struct {
 	SubAux                      []SubAux      `json:"subAux,omitempty"`
 	SubAuxAi                    []string      `json:"subAuxAi,omitempty"`
 	Obs                         []Observation `json:"obs,omitempty"`
+
+	ObsDtParsed        time.Time `json:"-"`
+	CreationDtParsed   time.Time `json:"-"`
+	LastEditedDtParsed time.Time `json:"-"`
 }
 
 type RegionalStatisticsOnDate struct {
@@ -70,6 +74,8 @@ type ChecklistFeedOnDate struct {
 	IsoObsDate      string      `json:"isoObsDate,omitempty"`
 	SubID           string      `json:"subID,omitempty"`
 	Loc             HotspotInfo `json:"loc,omitempty"`
+
+	ObsDtParsed time.Time `json:"-"`
 }
 
 type Top100 struct {
@@ -83,14 +89,19 @@ type Top100 struct {
 
 func (c *Client) Top100(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]Top100, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
 	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Top 100: %w", err)
+	}
+	regionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.Top100, regionCode, date.Year(), date.Month(), date.Day())
 	params := processOptions(opts...)
 
 	var top100 []Top100
-	err := c.get(ctx, endpoint, params.URLParams, &top100)
+	err = c.get(ctx, endpoint, params, &top100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Top 100: %w", err)
 	}
@@ -100,14 +111,19 @@ func (c *Client) Top100(ctx context.Context, regionCode string, date time.Time,
 
 func (c *Client) ChecklistFeedOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) ([]ChecklistFeedOnDate, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist feed: %w", err)
 	}
+	regionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.ChecklistFeedOnDate, regionCode, date.Year(), date.Month(), date.Day())
 	params := processOptions(opts...)
 
 	var feed []ChecklistFeedOnDate
-	err := c.get(ctx, endpoint, params.URLParams, &feed)
+	err = c.get(ctx, endpoint, params, &feed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get checklist feed: %w", err)
 	}
@@ -117,14 +133,19 @@ func (c *Client) ChecklistFeedOnDate(ctx context.Context, regionCode string, dat
 
 func (c *Client) RegionalStatisticsOnDate(ctx context.Context, regionCode string, date time.Time, opts ...RequestOption) (*RegionalStatisticsOnDate, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regional statistics: %w", err)
 	}
+	regionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.RegionalStatisticsOnDate, regionCode, date.Year(), date.Month(), date.Day())
 	params := processOptions(opts...)
 
 	var stats RegionalStatisticsOnDate
-	err := c.get(ctx, endpoint, params.URLParams, &stats)
+	err = c.get(ctx, endpoint, params, &stats)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get regional statistics: %w", err)
 	}
@@ -134,14 +155,19 @@ func (c *Client) RegionalStatisticsOnDate(ctx context.Context, regionCode string
 
 func (c *Client) SpeciesListForRegion(ctx context.Context, regionCode string, opts ...RequestOption) ([]string, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
+	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get species list for region: %w", err)
 	}
+	regionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.SpeciesListForRegion, regionCode)
 	params := processOptions(opts...)
 
 	var speciesList []string
-	err := c.get(ctx, endpoint, params.URLParams, &speciesList)
+	err = c.get(ctx, endpoint, params, &speciesList)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get species list for region: %w", err)
 	}
@@ -151,14 +177,14 @@ func (c *Client) SpeciesListForRegion(ctx context.Context, regionCode string, op
 
 func (c *Client) ViewChecklist(ctx context.Context, subId string, opts ...RequestOption) (*ViewChecklist, error) {
 	if subId == "" {
-		return nil, fmt.Errorf("subId cannot be empty")
+		return nil, invalidArgument("subId cannot be empty")
 	}
 
 	endpoint := fmt.Sprintf(APIEndpoints.ViewChecklist, subId)
 	params := processOptions(opts...)
 
 	var checklist ViewChecklist
-	err := c.get(ctx, endpoint, params.URLParams, &checklist)
+	err := c.get(ctx, endpoint, params, &checklist)
 	if err != nil {
 		return nil, fmt.Errorf("failed to view checklist: %w", err)
 	}
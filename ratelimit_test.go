@@ -0,0 +1,112 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(2, 2)
+	limiter.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	assert.Equal(t, time.Second, limiter.reserve())
+
+	now = now.Add(5 * time.Second)
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+}
+
+func TestRateLimiterWaitRespectsContext(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	var limiter *RateLimiter
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	limiter = NewRateLimiter(0, 1)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+}
+
+func TestClientWithSharedRateLimiter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(0.001, 2)
+	clientA, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithRateLimiter(limiter))
+	require.NoError(t, err)
+	clientB, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithRateLimiter(limiter))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = clientA.RecentObservationsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+	_, err = clientB.HotspotsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = clientA.ViewChecklist(ctx, "S123")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestClientWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithRateLimit(50, 1))
+	require.NoError(t, err)
+	require.NotNil(t, client.rateLimiter)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.RecentObservationsInRegion(context.Background(), "US-NY")
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.Allow())
+	assert.True(t, limiter.Allow())
+	assert.False(t, limiter.Allow())
+	assert.False(t, limiter.Allow())
+
+	now = now.Add(time.Second)
+	assert.True(t, limiter.Allow())
+	assert.False(t, limiter.Allow())
+}
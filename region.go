@@ -28,14 +28,19 @@ type SubRegion struct {
 
 func (c *Client) RegionInfo(ctx context.Context, regionCode string, opts ...RequestOption) (*RegionInfo, error) {
 	if regionCode == "" {
-		return nil, fmt.Errorf("regionCode cannot be empty")
+		return nil, invalidArgument("regionCode cannot be empty")
 	}
+	code, err := ParseRegionCode(regionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get region info: %w", err)
+	}
+	regionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.RegionInfo, regionCode)
 	params := processOptions(opts...)
 
 	var info RegionInfo
-	err := c.get(ctx, endpoint, params.URLParams, &info)
+	err = c.get(ctx, endpoint, params, &info)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get region info: %w", err)
 	}
@@ -45,17 +50,22 @@ func (c *Client) RegionInfo(ctx context.Context, regionCode string, opts ...Requ
 
 func (c *Client) SubRegionList(ctx context.Context, regionType, parentRegionCode string, opts ...RequestOption) ([]SubRegion, error) {
 	if regionType == "" {
-		return nil, fmt.Errorf("regionType cannot be empty")
+		return nil, invalidArgument("regionType cannot be empty")
 	}
 	if parentRegionCode == "" {
-		return nil, fmt.Errorf("parentRegionCode cannot be empty")
+		return nil, invalidArgument("parentRegionCode cannot be empty")
+	}
+	code, err := ParseRegionCode(parentRegionCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subregion list: %w", err)
 	}
+	parentRegionCode = code.String()
 
 	endpoint := fmt.Sprintf(APIEndpoints.SubRegionInfo, regionType, parentRegionCode)
 	params := processOptions(opts...)
 
 	var subRegions []SubRegion
-	err := c.get(ctx, endpoint, params.URLParams, &subRegions)
+	err = c.get(ctx, endpoint, params, &subRegions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subregion list: %w", err)
 	}
@@ -0,0 +1,155 @@
+package ebird
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegionType is the level of a region code. The values match the regionType
+// accepted by SubRegionList.
+type RegionType string
+
+const (
+	RegionTypeWorld        RegionType = "world"
+	RegionTypeCountry      RegionType = "country"
+	RegionTypeSubnational1 RegionType = "subnational1"
+	RegionTypeSubnational2 RegionType = "subnational2"
+	RegionTypeLocation     RegionType = "location"
+)
+
+const worldRegion RegionCode = "world"
+
+// RegionCode is an eBird region or location code such as "US", "US-NY",
+// "US-NY-109" or "L123456", with helpers to classify it and walk the region
+// hierarchy. The client methods keep taking region codes as plain strings,
+// so a RegionCode is passed with String(); they run the string through
+// ParseRegionCode themselves, which rejects malformed codes at run time
+// rather than at compile time.
+type RegionCode string
+
+// ParseRegionCode validates s and returns it in canonical form, with region
+// parts upper-cased. "world" is accepted as the parent of all countries.
+func ParseRegionCode(s string) (RegionCode, error) {
+	code := strings.TrimSpace(s)
+	if strings.EqualFold(code, string(worldRegion)) {
+		return worldRegion, nil
+	}
+	code = strings.ToUpper(code)
+	if RegionCode(code).Type() == "" {
+		return "", invalidArgument(fmt.Sprintf("invalid region code %q", s))
+	}
+	return RegionCode(code), nil
+}
+
+// Type classifies the code, or returns "" if it is malformed.
+func (r RegionCode) Type() RegionType {
+	s := string(r)
+	if r == worldRegion {
+		return RegionTypeWorld
+	}
+	if len(s) > 1 && s[0] == 'L' && isDigits(s[1:]) {
+		return RegionTypeLocation
+	}
+
+	parts := strings.Split(s, "-")
+	if len(parts[0]) != 2 || !isUpperLetters(parts[0]) {
+		return ""
+	}
+	for _, part := range parts[1:] {
+		if len(part) == 0 || len(part) > 6 || !isUpperAlnum(part) {
+			return ""
+		}
+	}
+	switch len(parts) {
+	case 1:
+		return RegionTypeCountry
+	case 2:
+		return RegionTypeSubnational1
+	case 3:
+		return RegionTypeSubnational2
+	}
+	return ""
+}
+
+func (r RegionCode) Valid() bool {
+	return r.Type() != ""
+}
+
+func (r RegionCode) String() string {
+	return string(r)
+}
+
+// Parent returns the enclosing region: the subnational1 of a subnational2,
+// the country of a subnational1 and "world" for a country. Locations and
+// "world" have no parent that can be derived from the code.
+func (r RegionCode) Parent() (RegionCode, bool) {
+	switch r.Type() {
+	case RegionTypeCountry:
+		return worldRegion, true
+	case RegionTypeSubnational1, RegionTypeSubnational2:
+		s := string(r)
+		return RegionCode(s[:strings.LastIndex(s, "-")]), true
+	}
+	return "", false
+}
+
+// Country returns the country part of a region code, or "" for locations
+// and "world".
+func (r RegionCode) Country() RegionCode {
+	switch r.Type() {
+	case RegionTypeCountry, RegionTypeSubnational1, RegionTypeSubnational2:
+		return RegionCode(string(r)[:2])
+	}
+	return ""
+}
+
+// Subnational1 returns the subnational1 part of a subnational1 or
+// subnational2 code, or "".
+func (r RegionCode) Subnational1() RegionCode {
+	switch r.Type() {
+	case RegionTypeSubnational1:
+		return r
+	case RegionTypeSubnational2:
+		parent, _ := r.Parent()
+		return parent
+	}
+	return ""
+}
+
+// Contains reports whether other is r or lies within it.
+func (r RegionCode) Contains(other RegionCode) bool {
+	if r == worldRegion {
+		return other.Valid()
+	}
+	if !r.Valid() || r.Type() == RegionTypeLocation {
+		return r == other
+	}
+	return other == r || strings.HasPrefix(string(other), string(r)+"-")
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isUpperLetters(s string) bool {
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperAlnum(s string) bool {
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegionCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     RegionCode
+		wantType RegionType
+		parent   RegionCode
+		country  RegionCode
+	}{
+		{input: "US", want: "US", wantType: RegionTypeCountry, parent: "world", country: "US"},
+		{input: "us-ny", want: "US-NY", wantType: RegionTypeSubnational1, parent: "US", country: "US"},
+		{input: "US-NY-109", want: "US-NY-109", wantType: RegionTypeSubnational2, parent: "US-NY", country: "US"},
+		{input: "GB-ENG-LND", want: "GB-ENG-LND", wantType: RegionTypeSubnational2, parent: "GB-ENG", country: "GB"},
+		{input: " L123456 ", want: "L123456", wantType: RegionTypeLocation},
+		{input: "World", want: "world", wantType: RegionTypeWorld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRegionCode(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantType, got.Type())
+			assert.True(t, got.Valid())
+
+			parent, ok := got.Parent()
+			assert.Equal(t, tt.parent != "", ok)
+			assert.Equal(t, tt.parent, parent)
+			assert.Equal(t, tt.country, got.Country())
+		})
+	}
+
+	for _, input := range []string{"", "INVALID", "U", "USA-NY", "US-", "US-NY-109-1", "US-N Y", "L", "L12a", "US-NEWYORK"} {
+		_, err := ParseRegionCode(input)
+		assert.ErrorIs(t, err, ErrInvalidArgument, input)
+		assert.False(t, RegionCode(input).Valid(), input)
+	}
+}
+
+func TestRegionCodeHierarchy(t *testing.T) {
+	assert.Equal(t, RegionCode("US-NY"), RegionCode("US-NY-109").Subnational1())
+	assert.Equal(t, RegionCode("US-NY"), RegionCode("US-NY").Subnational1())
+	assert.Equal(t, RegionCode(""), RegionCode("US").Subnational1())
+
+	assert.True(t, RegionCode("US").Contains("US-NY-109"))
+	assert.True(t, RegionCode("US-NY").Contains("US-NY"))
+	assert.False(t, RegionCode("US-NY").Contains("US-NYC"))
+	assert.False(t, RegionCode("US-NY-109").Contains("US-NY"))
+	assert.True(t, RegionCode("world").Contains("TW"))
+	assert.False(t, RegionCode("L123").Contains("L1234"))
+}
+
+func TestMalformedRegionCodeRejectedBeforeRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	date := time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	_, err = client.RecentObservationsInRegion(ctx, "US-NY/../x")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.RecentNotableObservationsInRegion(ctx, "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.HistoricObservationsOnDate(ctx, "NEWYORK", date)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.Top100(ctx, "INVALID", date)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.Contains(t, err.Error(), `failed to get Top 100: invalid region code "INVALID"`)
+	_, err = client.HotspotsInRegion(ctx, "US NY")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.SubRegionList(ctx, string(RegionTypeSubnational1), "USA")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.AdjacentRegions(ctx, "US-")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.HistoricObservationsRange(ctx, "bad code", date, date)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestRegionCodeNormalizedInRequest(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	date := time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	_, err = client.RecentObservationsInRegion(ctx, "us-ny")
+	require.NoError(t, err)
+	_, err = client.ChecklistFeedOnDate(ctx, " us-ny-109 ", date)
+	require.NoError(t, err)
+	_, err = client.HotspotsInRegion(ctx, "ca-on")
+	require.NoError(t, err)
+	_, err = client.SubRegionList(ctx, string(RegionTypeSubnational2), "us-ny")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/data/obs/US-NY/recent",
+		"/product/lists/US-NY-109/2023/10/6",
+		"/ref/hotspot/CA-ON",
+		"/ref/region/list/subnational2/US-NY",
+	}, paths)
+}
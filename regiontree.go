@@ -0,0 +1,286 @@
+package ebird
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// RegionNode is one region in a RegionTree. Info is only set when the tree
+// was built with WithTreeBounds.
+type RegionNode struct {
+	Code     string        `json:"code"`
+	Name     string        `json:"name,omitempty"`
+	Type     RegionType    `json:"type"`
+	Info     *RegionInfo   `json:"info,omitempty"`
+	Children []*RegionNode `json:"children,omitempty"`
+
+	parent *RegionNode
+}
+
+// Parent returns the enclosing node, or nil for the root.
+func (n *RegionNode) Parent() *RegionNode {
+	return n.parent
+}
+
+// RegionTree is the country, subnational1 and subnational2 hierarchy below a
+// root region, as returned by SubRegionList. It marshals to JSON so that it
+// can be shipped as a static file and loaded with ReadRegionTree.
+type RegionTree struct {
+	Root *RegionNode
+
+	index map[string]*RegionNode
+}
+
+type RegionTreeOptions struct {
+	concurrency int
+	bounds      bool
+}
+
+type RegionTreeOption func(*RegionTreeOptions)
+
+// WithTreeConcurrency sets how many requests run at once while the tree is
+// built. The default is 4.
+func WithTreeConcurrency(n int) RegionTreeOption {
+	return func(o *RegionTreeOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithTreeBounds fetches RegionInfo for every node so that Info.Bounds is
+// set. This costs one extra request per region.
+func WithTreeBounds() RegionTreeOption {
+	return func(o *RegionTreeOptions) {
+		o.bounds = true
+	}
+}
+
+// RegionTree walks SubRegionList from root, such as "world", "US" or
+// "US-NY", down depth levels. A depth of zero or less walks down to
+// subnational2.
+func (c *Client) RegionTree(ctx context.Context, root string, depth int, opts ...RegionTreeOption) (*RegionTree, error) {
+	code, err := ParseRegionCode(root)
+	if err != nil {
+		return nil, err
+	}
+	if code.Type() == RegionTypeLocation {
+		return nil, invalidArgument("root must be a region, not a location")
+	}
+
+	options := &RegionTreeOptions{concurrency: defaultConcurrency}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	b := &treeBuilder{client: c, ctx: ctx, cancel: cancel, sem: make(chan struct{}, options.concurrency), bounds: options.bounds}
+
+	if depth <= 0 {
+		depth = -1
+	}
+	rootNode := &RegionNode{Code: code.String(), Type: code.Type()}
+	b.wg.Add(1)
+	go b.visit(rootNode, depth)
+	b.wg.Wait()
+
+	if b.err != nil {
+		return nil, fmt.Errorf("failed to build region tree: %w", b.err)
+	}
+	return newRegionTree(rootNode), nil
+}
+
+type treeBuilder struct {
+	client *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	bounds bool
+
+	wg  sync.WaitGroup
+	mu  sync.Mutex
+	err error
+}
+
+// childType returns the type of the regions directly below t.
+func childType(t RegionType) RegionType {
+	switch t {
+	case RegionTypeWorld:
+		return RegionTypeCountry
+	case RegionTypeCountry:
+		return RegionTypeSubnational1
+	case RegionTypeSubnational1:
+		return RegionTypeSubnational2
+	}
+	return ""
+}
+
+// visit fills in node and starts a visit for each of its children. depth is
+// the number of levels left to fetch below node; it is negative when there is
+// no limit.
+func (b *treeBuilder) visit(node *RegionNode, depth int) {
+	defer b.wg.Done()
+
+	if b.bounds && node.Type != RegionTypeWorld {
+		if !b.acquire() {
+			return
+		}
+		info, err := b.client.RegionInfo(b.ctx, node.Code)
+		b.release()
+		if err != nil {
+			b.fail(err)
+			return
+		}
+		node.Info = info
+	}
+
+	next := childType(node.Type)
+	if next == "" || depth == 0 {
+		return
+	}
+
+	if !b.acquire() {
+		return
+	}
+	subRegions, err := b.client.SubRegionList(b.ctx, string(next), node.Code)
+	b.release()
+	if err != nil {
+		b.fail(err)
+		return
+	}
+
+	node.Children = make([]*RegionNode, 0, len(subRegions))
+	for _, sr := range subRegions {
+		child := &RegionNode{Code: sr.Code, Name: sr.Name, Type: next}
+		node.Children = append(node.Children, child)
+		b.wg.Add(1)
+		go b.visit(child, depth-1)
+	}
+}
+
+// acquire takes one of the concurrency slots, or fails the build if the
+// context is done first.
+func (b *treeBuilder) acquire() bool {
+	select {
+	case b.sem <- struct{}{}:
+		return true
+	case <-b.ctx.Done():
+		b.fail(b.ctx.Err())
+		return false
+	}
+}
+
+func (b *treeBuilder) release() {
+	<-b.sem
+}
+
+func (b *treeBuilder) fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err == nil {
+		b.err = err
+		b.cancel()
+	}
+}
+
+func newRegionTree(root *RegionNode) *RegionTree {
+	t := &RegionTree{Root: root, index: make(map[string]*RegionNode)}
+	var link func(n, parent *RegionNode)
+	link = func(n, parent *RegionNode) {
+		n.parent = parent
+		t.index[n.Code] = n
+		for _, child := range n.Children {
+			link(child, n)
+		}
+	}
+	if root != nil {
+		link(root, nil)
+	}
+	return t
+}
+
+// Find returns the node for a region code.
+func (t *RegionTree) Find(code string) (*RegionNode, bool) {
+	n, ok := t.index[code]
+	return n, ok
+}
+
+// Path returns the nodes from the root down to code, or nil if code is not
+// in the tree.
+func (t *RegionTree) Path(code string) []*RegionNode {
+	n, ok := t.index[code]
+	if !ok {
+		return nil
+	}
+	var path []*RegionNode
+	for ; n != nil; n = n.parent {
+		path = append([]*RegionNode{n}, path...)
+	}
+	return path
+}
+
+// Len returns the number of nodes, including the root.
+func (t *RegionTree) Len() int {
+	return len(t.index)
+}
+
+// Walk calls fn for every node in depth-first order, parents before
+// children. Returning false skips the node's children.
+func (t *RegionTree) Walk(fn func(*RegionNode) bool) {
+	var walk func(n *RegionNode)
+	walk = func(n *RegionNode) {
+		if !fn(n) {
+			return
+		}
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	if t.Root != nil {
+		walk(t.Root)
+	}
+}
+
+// Hash returns a digest of the tree's contents. Two trees with the same
+// regions, names and bounds have the same hash, so a refreshed tree only
+// needs to be shipped when the hash changes.
+func (t *RegionTree) Hash() string {
+	data, _ := json.Marshal(t.Root)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *RegionTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Root)
+}
+
+func (t *RegionTree) UnmarshalJSON(data []byte) error {
+	var root *RegionNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	*t = *newRegionTree(root)
+	return nil
+}
+
+func (t *RegionTree) WriteJSON(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(t); err != nil {
+		return fmt.Errorf("failed to encode region tree: %w", err)
+	}
+	return nil
+}
+
+// ReadRegionTree loads a tree written with WriteJSON.
+func ReadRegionTree(r io.Reader) (*RegionTree, error) {
+	var t RegionTree
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to decode region tree: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,165 @@
+package ebird
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSubRegions = map[string]string{
+	"country/world":      `[{"code":"CA","name":"Canada"},{"code":"US","name":"United States"}]`,
+	"subnational1/CA":    `[{"code":"CA-AB","name":"Alberta"}]`,
+	"subnational1/US":    `[{"code":"US-CT","name":"Connecticut"},{"code":"US-NY","name":"New York"}]`,
+	"subnational2/CA-AB": `[]`,
+	"subnational2/US-CT": `[{"code":"US-CT-001","name":"Fairfield"}]`,
+	"subnational2/US-NY": `[{"code":"US-NY-109","name":"Tompkins"},{"code":"US-NY-061","name":"New York"}]`,
+}
+
+func newRegionTreeServer(t *testing.T, maxInFlight *int32) *httptest.Server {
+	var inFlight int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/ref/region/list/"):
+			body, ok := testSubRegions[strings.TrimPrefix(r.URL.Path, "/ref/region/list/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		case strings.HasPrefix(r.URL.Path, "/ref/region/info/"):
+			code := strings.TrimPrefix(r.URL.Path, "/ref/region/info/")
+			fmt.Fprintf(w, `{"code":%q,"result":%q,"bounds":{"minX":-80,"maxX":-70,"minY":40,"maxY":45}}`, code, code)
+		}
+	}))
+}
+
+func TestRegionTree(t *testing.T) {
+	var maxInFlight int32
+	server := newRegionTreeServer(t, &maxInFlight)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	tree, err := client.RegionTree(context.Background(), "world", 0, WithTreeConcurrency(2))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	assert.Equal(t, 9, tree.Len())
+	assert.Equal(t, "world", tree.Root.Code)
+	require.Len(t, tree.Root.Children, 2)
+	assert.Equal(t, "Canada", tree.Root.Children[0].Name)
+
+	node, ok := tree.Find("US-NY-109")
+	require.True(t, ok)
+	assert.Equal(t, RegionTypeSubnational2, node.Type)
+	assert.Equal(t, "US-NY", node.Parent().Code)
+	assert.Nil(t, node.Info)
+
+	var path []string
+	for _, n := range tree.Path("US-NY-109") {
+		path = append(path, n.Code)
+	}
+	assert.Equal(t, []string{"world", "US", "US-NY", "US-NY-109"}, path)
+	assert.Nil(t, tree.Path("MX"))
+
+	var visited []string
+	tree.Walk(func(n *RegionNode) bool {
+		visited = append(visited, n.Code)
+		return n.Code != "US"
+	})
+	assert.Equal(t, []string{"world", "CA", "CA-AB", "US"}, visited)
+}
+
+func TestRegionTreeDepthAndBounds(t *testing.T) {
+	var maxInFlight int32
+	server := newRegionTreeServer(t, &maxInFlight)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	tree, err := client.RegionTree(context.Background(), "US", 1, WithTreeBounds())
+	require.NoError(t, err)
+	assert.Equal(t, 3, tree.Len())
+
+	node, ok := tree.Find("US-CT")
+	require.True(t, ok)
+	assert.Empty(t, node.Children)
+	require.NotNil(t, node.Info)
+	assert.Equal(t, -80.0, node.Info.Bounds.MinX)
+	require.NotNil(t, tree.Root.Info)
+}
+
+func TestRegionTreeJSON(t *testing.T) {
+	var maxInFlight int32
+	server := newRegionTreeServer(t, &maxInFlight)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	tree, err := client.RegionTree(context.Background(), "US", 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tree.WriteJSON(&buf))
+	loaded, err := ReadRegionTree(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, tree.Len(), loaded.Len())
+	assert.Equal(t, tree.Hash(), loaded.Hash())
+	node, ok := loaded.Find("US-CT-001")
+	require.True(t, ok)
+	assert.Equal(t, "US-CT", node.Parent().Code)
+
+	again, err := client.RegionTree(context.Background(), "US", 0)
+	require.NoError(t, err)
+	assert.Equal(t, tree.Hash(), again.Hash())
+
+	node.Name = "Renamed"
+	assert.NotEqual(t, tree.Hash(), loaded.Hash())
+
+	data, err := json.Marshal(loaded)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"code":"US"`)
+}
+
+func TestRegionTreeErrors(t *testing.T) {
+	var maxInFlight int32
+	server := newRegionTreeServer(t, &maxInFlight)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.RegionTree(ctx, "MX", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "failed to build region tree")
+
+	_, err = client.RegionTree(ctx, "L123", 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.RegionTree(ctx, "not a region", 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,277 @@
+package ebird
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Sources a ResolvedRegion can be derived from.
+const (
+	ResolveSourceBounds   = "bounds"
+	ResolveSourceHotspots = "hotspots"
+	ResolveSourceCombined = "bounds+hotspots"
+)
+
+const defaultResolverDistance = 25
+
+// ResolvedRegion is the region a point was found to be in. Code is the most
+// specific region that could be determined. Confidence is between 0 and 1.
+type ResolvedRegion struct {
+	Code         string
+	Type         RegionType
+	Country      string
+	Subnational1 string
+	Subnational2 string
+	Confidence   float64
+	Source       string
+}
+
+// RegionResolver finds the eBird region containing a point. It narrows the
+// candidates using the bounds in a RegionTree built with WithTreeBounds, and
+// falls back to the regions of nearby hotspots when the bounds overlap or no
+// tree is available.
+type RegionResolver struct {
+	client   *Client
+	tree     *RegionTree
+	distance int
+}
+
+type RegionResolverOption func(*RegionResolver)
+
+// WithResolverDistance sets the radius in kilometers searched for hotspots.
+// The default is 25.
+func WithResolverDistance(km int) RegionResolverOption {
+	return func(r *RegionResolver) {
+		if km > 0 {
+			r.distance = km
+		}
+	}
+}
+
+// NewRegionResolver creates a resolver. tree may be nil, in which case only
+// nearby hotspots are used.
+func NewRegionResolver(client *Client, tree *RegionTree, opts ...RegionResolverOption) *RegionResolver {
+	r := &RegionResolver{client: client, tree: tree, distance: defaultResolverDistance}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the region containing lat, lng. It returns an error
+// matching ErrNotFound if neither the tree nor nearby hotspots place the
+// point in a region.
+func (r *RegionResolver) Resolve(ctx context.Context, lat, lng float64) (*ResolvedRegion, error) {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, invalidArgument(fmt.Sprintf("invalid coordinates %v,%v", lat, lng))
+	}
+
+	candidates := r.boundsCandidates(lat, lng)
+	if len(candidates) == 1 {
+		return newResolvedRegion(candidates[0].Code, 0.9, ResolveSourceBounds), nil
+	}
+
+	hotspots, err := r.client.NearbyHotspots(ctx, Lat(lat), Lng(lng), Dist(r.distance))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve region: %w", err)
+	}
+
+	allowed := make(map[string]bool)
+	for _, n := range candidates {
+		for p := n; p != nil; p = p.parent {
+			allowed[p.Code] = true
+		}
+	}
+	winner, share, nearest := voteHotspots(hotspots, lat, lng, allowed)
+	if winner == "" && len(allowed) > 0 {
+		winner, share, nearest = voteHotspots(hotspots, lat, lng, nil)
+	}
+
+	if winner == "" {
+		if code := commonAncestor(candidates); code != "" {
+			return newResolvedRegion(code, 0.5, ResolveSourceBounds), nil
+		}
+		return nil, fmt.Errorf("no region found at %v,%v: %w", lat, lng, ErrNotFound)
+	}
+
+	confidence := 0.3 + 0.5*share
+	source := ResolveSourceHotspots
+	code := winner
+	var within []*RegionNode
+	for _, n := range candidates {
+		if RegionCode(winner).Contains(RegionCode(n.Code)) {
+			within = append(within, n)
+		}
+	}
+	if len(within) > 0 {
+		confidence = 0.5 + 0.4*share
+		source = ResolveSourceCombined
+		if len(within) == 1 {
+			code = within[0].Code
+		}
+	}
+
+	// Hotspots far from the point say less about where it is.
+	if nearest > 5 {
+		confidence *= math.Max(0.5, 5/nearest)
+	}
+	return newResolvedRegion(code, confidence, source), nil
+}
+
+// boundsCandidates returns the deepest nodes whose bounds contain the point.
+// Nodes without RegionInfo are only descended into if they are the root.
+func (r *RegionResolver) boundsCandidates(lat, lng float64) []*RegionNode {
+	if r.tree == nil || r.tree.Root == nil {
+		return nil
+	}
+
+	var matches []*RegionNode
+	var visit func(n *RegionNode) bool
+	visit = func(n *RegionNode) bool {
+		if n != r.tree.Root && (n.Info == nil || !boundsContain(n.Info.Bounds, lat, lng)) {
+			return false
+		}
+		deeper := false
+		for _, child := range n.Children {
+			if visit(child) {
+				deeper = true
+			}
+		}
+		if !deeper && n != r.tree.Root {
+			matches = append(matches, n)
+		}
+		return true
+	}
+	visit(r.tree.Root)
+
+	// Keep only the most specific level that matched.
+	depth := func(n *RegionNode) int {
+		d := 0
+		for p := n.parent; p != nil; p = p.parent {
+			d++
+		}
+		return d
+	}
+	max := 0
+	for _, n := range matches {
+		if d := depth(n); d > max {
+			max = d
+		}
+	}
+	var deepest []*RegionNode
+	for _, n := range matches {
+		if depth(n) == max {
+			deepest = append(deepest, n)
+		}
+	}
+	return deepest
+}
+
+func boundsContain(b Bounds, lat, lng float64) bool {
+	if lat < b.MinY || lat > b.MaxY {
+		return false
+	}
+	if b.MinX <= b.MaxX {
+		return lng >= b.MinX && lng <= b.MaxX
+	}
+	// The bounds cross the antimeridian.
+	return lng >= b.MinX || lng <= b.MaxX
+}
+
+// voteHotspots picks the region most of the nearby hotspots are in,
+// weighting closer hotspots more. Each hotspot votes for its most specific
+// region. If allowed is not empty a hotspot votes for the most specific of
+// its regions in allowed instead, and hotspots with none are skipped. It
+// returns the winner, its share of the vote and the distance in km to the
+// nearest counted hotspot.
+func voteHotspots(hotspots []NearbyHotspot, lat, lng float64, allowed map[string]bool) (string, float64, float64) {
+	votes := make(map[string]float64)
+	var total float64
+	nearest := math.Inf(1)
+	for _, h := range hotspots {
+		code := hotspotRegion(h, allowed)
+		if code == "" {
+			continue
+		}
+		d := distanceKm(lat, lng, h.Lat, h.Lng)
+		w := 1 / (1 + d)
+		votes[code] += w
+		total += w
+		nearest = math.Min(nearest, d)
+	}
+	if total == 0 {
+		return "", 0, 0
+	}
+
+	codes := make([]string, 0, len(votes))
+	for code := range votes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if votes[codes[i]] != votes[codes[j]] {
+			return votes[codes[i]] > votes[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+	return codes[0], votes[codes[0]] / total, nearest
+}
+
+// hotspotRegion returns the most specific region of h, or of its regions in
+// allowed if allowed is not empty.
+func hotspotRegion(h NearbyHotspot, allowed map[string]bool) string {
+	for _, code := range []string{h.Subnational2Code, h.Subnational1Code, h.CountryCode} {
+		if code != "" && (len(allowed) == 0 || allowed[code]) {
+			return code
+		}
+	}
+	return ""
+}
+
+// commonAncestor returns the most specific region containing every node.
+func commonAncestor(nodes []*RegionNode) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+	for p := nodes[0]; p != nil; p = p.parent {
+		all := true
+		for _, n := range nodes[1:] {
+			if !RegionCode(p.Code).Contains(RegionCode(n.Code)) {
+				all = false
+				break
+			}
+		}
+		if all && p.Type != RegionTypeWorld {
+			return p.Code
+		}
+	}
+	return ""
+}
+
+func newResolvedRegion(code string, confidence float64, source string) *ResolvedRegion {
+	rc := RegionCode(code)
+	resolved := &ResolvedRegion{
+		Code:         code,
+		Type:         rc.Type(),
+		Country:      rc.Country().String(),
+		Subnational1: rc.Subnational1().String(),
+		Confidence:   confidence,
+		Source:       source,
+	}
+	if resolved.Type == RegionTypeSubnational2 {
+		resolved.Subnational2 = code
+	}
+	return resolved
+}
+
+// distanceKm returns the great-circle distance between two points.
+func distanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
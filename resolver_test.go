@@ -0,0 +1,153 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const resolverTreeJSON = `{"code":"world","type":"world","children":[
+	{"code":"US","type":"country","info":{"bounds":{"minX":-125,"maxX":-66,"minY":24,"maxY":50}},"children":[
+		{"code":"US-NY","type":"subnational1","info":{"bounds":{"minX":-80,"maxX":-71.8,"minY":40.5,"maxY":45}},"children":[
+			{"code":"US-NY-109","type":"subnational2","info":{"bounds":{"minX":-76.7,"maxX":-76.2,"minY":42.2,"maxY":42.6}}},
+			{"code":"US-NY-019","type":"subnational2","info":{"bounds":{"minX":-74.1,"maxX":-73.3,"minY":44.4,"maxY":45}}}
+		]},
+		{"code":"US-VT","type":"subnational1","info":{"bounds":{"minX":-73.5,"maxX":-71.4,"minY":42.7,"maxY":45.1}},"children":[
+			{"code":"US-VT-007","type":"subnational2","info":{"bounds":{"minX":-73.4,"maxX":-72.8,"minY":44.2,"maxY":44.7}}}
+		]}
+	]},
+	{"code":"CA","type":"country","info":{"bounds":{"minX":-141,"maxX":-52,"minY":41,"maxY":84}},"children":[
+		{"code":"CA-ON","type":"subnational1","info":{"bounds":{"minX":-95,"maxX":-74.3,"minY":41.7,"maxY":56.9}}}
+	]}
+]}`
+
+func newResolverServer(t *testing.T, body string, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		assert.Equal(t, "/ref/hotspot/geo", r.URL.Path)
+		assert.NotEmpty(t, r.URL.Query().Get("lat"))
+		assert.NotEmpty(t, r.URL.Query().Get("dist"))
+		w.Write([]byte(body))
+	}))
+}
+
+func TestRegionResolver(t *testing.T) {
+	tree, err := ReadRegionTree(strings.NewReader(resolverTreeJSON))
+	require.NoError(t, err)
+
+	var calls int32
+	server := newResolverServer(t, `[
+		{"locId":"L1","countryCode":"US","subnational1Code":"US-VT","lat":44.48,"lng":-73.22},
+		{"locId":"L2","countryCode":"US","subnational1Code":"US-VT","lat":44.5,"lng":-73.3},
+		{"locId":"L3","countryCode":"US","subnational1Code":"US-NY","lat":44.6,"lng":-73.5}
+	]`, &calls)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	resolver := NewRegionResolver(client, tree, WithResolverDistance(10))
+	ctx := context.Background()
+
+	got, err := resolver.Resolve(ctx, 42.44, -76.5)
+	require.NoError(t, err)
+	assert.Equal(t, &ResolvedRegion{
+		Code:         "US-NY-109",
+		Type:         RegionTypeSubnational2,
+		Country:      "US",
+		Subnational1: "US-NY",
+		Subnational2: "US-NY-109",
+		Confidence:   0.9,
+		Source:       ResolveSourceBounds,
+	}, got)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "unambiguous bounds should not need hotspots")
+
+	got, err = resolver.Resolve(ctx, 44.5, -73.35)
+	require.NoError(t, err)
+	assert.Equal(t, "US-VT-007", got.Code)
+	assert.Equal(t, "US-VT", got.Subnational1)
+	assert.Equal(t, ResolveSourceCombined, got.Source)
+	assert.Greater(t, got.Confidence, 0.5)
+	assert.Less(t, got.Confidence, 0.9)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = resolver.Resolve(ctx, 91, 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestRegionResolverOverlappingCounties(t *testing.T) {
+	tree, err := ReadRegionTree(strings.NewReader(`{"code":"world","type":"world","children":[
+		{"code":"US","type":"country","info":{"bounds":{"minX":-125,"maxX":-66,"minY":24,"maxY":50}},"children":[
+			{"code":"US-NY","type":"subnational1","info":{"bounds":{"minX":-80,"maxX":-71.8,"minY":40.5,"maxY":45}},"children":[
+				{"code":"US-NY-109","type":"subnational2","info":{"bounds":{"minX":-76.7,"maxX":-76.2,"minY":42.2,"maxY":42.6}}},
+				{"code":"US-NY-023","type":"subnational2","info":{"bounds":{"minX":-76.3,"maxX":-75.8,"minY":42.4,"maxY":42.8}}}
+			]}
+		]}
+	]}`))
+	require.NoError(t, err)
+
+	var calls int32
+	server := newResolverServer(t, `[
+		{"locId":"L1","countryCode":"US","subnational1Code":"US-NY","subnational2Code":"US-NY-023","lat":42.5,"lng":-76.24},
+		{"locId":"L2","countryCode":"US","subnational1Code":"US-NY","subnational2Code":"US-NY-023","lat":42.52,"lng":-76.22},
+		{"locId":"L3","countryCode":"US","subnational1Code":"US-NY","subnational2Code":"US-NY-109","lat":42.45,"lng":-76.35}
+	]`, &calls)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	got, err := NewRegionResolver(client, tree).Resolve(context.Background(), 42.5, -76.25)
+	require.NoError(t, err)
+	assert.Equal(t, "US-NY-023", got.Code)
+	assert.Equal(t, RegionTypeSubnational2, got.Type)
+	assert.Equal(t, "US-NY-023", got.Subnational2)
+	assert.Equal(t, ResolveSourceCombined, got.Source)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRegionResolverHotspotsOnly(t *testing.T) {
+	var calls int32
+	server := newResolverServer(t, `[
+		{"locId":"L1","countryCode":"US","subnational1Code":"US-NY","lat":42.45,"lng":-76.5},
+		{"locId":"L2","countryCode":"US","subnational1Code":"US-PA","lat":42.0,"lng":-76.5}
+	]`, &calls)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	got, err := NewRegionResolver(client, nil).Resolve(context.Background(), 42.44, -76.5)
+	require.NoError(t, err)
+	assert.Equal(t, "US-NY", got.Code)
+	assert.Equal(t, RegionTypeSubnational1, got.Type)
+	assert.Equal(t, "", got.Subnational2)
+	assert.Equal(t, ResolveSourceHotspots, got.Source)
+	assert.Greater(t, got.Confidence, 0.3)
+}
+
+func TestRegionResolverNoHotspots(t *testing.T) {
+	tree, err := ReadRegionTree(strings.NewReader(resolverTreeJSON))
+	require.NoError(t, err)
+
+	var calls int32
+	server := newResolverServer(t, `[]`, &calls)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	got, err := NewRegionResolver(client, tree).Resolve(ctx, 44.5, -73.35)
+	require.NoError(t, err)
+	assert.Equal(t, "US", got.Code)
+	assert.Equal(t, 0.5, got.Confidence)
+
+	_, err = NewRegionResolver(client, nil).Resolve(ctx, 0, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,145 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy controls how Client retries failed requests. Only idempotent
+// failures are retried: timeouts, connection resets, 429 and 502/503/504.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Jitter is the fraction (0 to 1) of each backoff that is randomized.
+	Jitter float64
+	// OnAttempt, if set, is called after every attempt, including the last.
+	OnAttempt func(RetryAttempt)
+}
+
+// RetryAttempt describes the outcome of a single request attempt.
+type RetryAttempt struct {
+	Attempt    int
+	StatusCode int
+	Err        error
+	Retrying   bool
+	Delay      time.Duration
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if policy.BaseBackoff <= 0 {
+			policy.BaseBackoff = defaultRetryBaseBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if policy.Jitter < 0 {
+			policy.Jitter = 0
+		}
+		if policy.Jitter > 1 {
+			policy.Jitter = 1
+		}
+		client.retryPolicy = &policy
+	}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	delay := p.BaseBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+func (p *RetryPolicy) notify(attempt RetryAttempt) {
+	if p != nil && p.OnAttempt != nil {
+		p.OnAttempt(attempt)
+	}
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isRetryableError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
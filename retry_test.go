@@ -0,0 +1,218 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxAttempts  int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{
+			name:         "Retries on 503 then succeeds",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			maxAttempts:  3,
+			wantRequests: 3,
+		},
+		{
+			name:         "Retries on 429",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			maxAttempts:  3,
+			wantRequests: 2,
+		},
+		{
+			name:         "Gives up after max attempts",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxAttempts:  2,
+			wantErr:      true,
+			wantRequests: 2,
+		},
+		{
+			name:         "Does not retry 400",
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			maxAttempts:  3,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "Does not retry 500",
+			statuses:     []int{http.StatusInternalServerError, http.StatusOK},
+			maxAttempts:  3,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&requests, 1)
+				status := tt.statuses[n-1]
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					w.Write([]byte(`{"key":"value"}`))
+				}
+			}))
+			defer server.Close()
+
+			var attempts []RetryAttempt
+			client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithRetryPolicy(RetryPolicy{
+				MaxAttempts: tt.maxAttempts,
+				BaseBackoff: time.Millisecond,
+				MaxBackoff:  5 * time.Millisecond,
+				OnAttempt: func(a RetryAttempt) {
+					attempts = append(attempts, a)
+				},
+			}))
+			require.NoError(t, err)
+
+			var result map[string]string
+			err = client.get(context.Background(), "test", RequestOptions{}, &result)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "value", result["key"])
+			}
+
+			assert.Equal(t, tt.wantRequests, atomic.LoadInt32(&requests))
+			require.Len(t, attempts, int(tt.wantRequests))
+			for i, a := range attempts {
+				assert.Equal(t, i+1, a.Attempt)
+				assert.Equal(t, i < len(attempts)-1, a.Retrying)
+			}
+		})
+	}
+}
+
+func TestClientRetryDisabledByDefault(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	var result map[string]string
+	err = client.get(context.Background(), "test", RequestOptions{}, &result)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestClientRetryHonorsRetryAfter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithRetryPolicy(RetryPolicy{
+		BaseBackoff: time.Millisecond,
+		OnAttempt: func(a RetryAttempt) {
+			delays = append(delays, a.Delay)
+		},
+	}))
+	require.NoError(t, err)
+
+	var result map[string]string
+	err = client.get(context.Background(), "test", RequestOptions{}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 0}, delays)
+}
+
+func TestClientRetryStopsOnContextCancel(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 5,
+		BaseBackoff: time.Hour,
+		OnAttempt: func(a RetryAttempt) {
+			cancel()
+		},
+	}))
+	require.NoError(t, err)
+
+	var result map[string]string
+	err = client.get(ctx, "test", RequestOptions{}, &result)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestClientRetryOnHTTPClientTimeout(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"key":"value"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/"),
+		WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+	require.NoError(t, err)
+
+	var result map[string]string
+	err = client.get(context.Background(), "test", RequestOptions{}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, "value", result["key"])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, 0))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2, 0))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3, 0))
+	assert.Equal(t, time.Second, policy.backoff(10, 0))
+	assert.Equal(t, 3*time.Second, policy.backoff(1, 3*time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		d := policy.backoff(2, 0)
+		assert.True(t, d > 100*time.Millisecond && d <= 200*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-1"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	d := parseRetryAfter(future)
+	assert.True(t, d > 50*time.Second && d <= time.Minute)
+}
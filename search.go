@@ -0,0 +1,314 @@
+package ebird
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultSearchLimit    = 10
+	defaultSearchMinScore = 0.3
+)
+
+// Fields a SearchResult can be matched on.
+const (
+	SearchFieldComName      = "comName"
+	SearchFieldSciName      = "sciName"
+	SearchFieldSpeciesCode  = "speciesCode"
+	SearchFieldBandingCode  = "bandingCode"
+	SearchFieldComNameCode  = "comNameCode"
+	SearchFieldSciNameCode  = "sciNameCode"
+	SearchFieldLocalComName = "localComName"
+)
+
+// SearchResult is one candidate for a search query. Score is between 0 and 1
+// where 1 is an exact match.
+type SearchResult struct {
+	Taxon   EbirdTaxon
+	Score   float64
+	Field   string
+	Matched string
+}
+
+type searchOptions struct {
+	limit      int
+	minScore   float64
+	categories []string
+}
+
+type SearchOption func(*searchOptions)
+
+// SearchLimit sets the maximum number of results. The default is 10; zero or
+// less returns every result above the minimum score.
+func SearchLimit(n int) SearchOption {
+	return func(o *searchOptions) {
+		o.limit = n
+	}
+}
+
+// SearchMinScore drops results that score below min. The default is 0.3.
+func SearchMinScore(min float64) SearchOption {
+	return func(o *searchOptions) {
+		o.minScore = min
+	}
+}
+
+// SearchCategories restricts results to taxa in the given categories, such as
+// "species", "issf", "slash", "spuh" or "hybrid".
+func SearchCategories(categories ...string) SearchOption {
+	return func(o *searchOptions) {
+		o.categories = append(o.categories, categories...)
+	}
+}
+
+type searchName struct {
+	field  string
+	value  string
+	norm   string
+	tokens []string
+}
+
+type searchEntry struct {
+	taxon EbirdTaxon
+	names []searchName
+	codes []searchName
+}
+
+// Searcher ranks taxa against user-typed names. It matches prefixes, word
+// abbreviations and small typos in common and scientific names, and exact
+// species, banding and name codes.
+type Searcher struct {
+	entries []searchEntry
+}
+
+// NewSearcher builds a Searcher over the taxa of t. Each of localized is a
+// taxonomy fetched with the Locale option; its common names are matched in
+// addition to those in t.
+func NewSearcher(t *Taxonomy, localized ...[]EbirdTaxon) *Searcher {
+	local := make(map[string][]string)
+	for _, taxa := range localized {
+		for _, taxon := range taxa {
+			local[taxon.SpeciesCode] = append(local[taxon.SpeciesCode], taxon.ComName)
+		}
+	}
+
+	s := &Searcher{entries: make([]searchEntry, 0, t.Len())}
+	for _, taxon := range t.taxa {
+		entry := searchEntry{taxon: taxon}
+		entry.addName(SearchFieldComName, taxon.ComName)
+		entry.addName(SearchFieldSciName, taxon.SciName)
+		for _, name := range local[taxon.SpeciesCode] {
+			if normalizeSearch(name) != normalizeSearch(taxon.ComName) {
+				entry.addName(SearchFieldLocalComName, name)
+			}
+		}
+
+		entry.addCode(SearchFieldSpeciesCode, taxon.SpeciesCode)
+		for _, code := range taxon.BandingCodes {
+			entry.addCode(SearchFieldBandingCode, code)
+		}
+		for _, code := range taxon.ComNameCodes {
+			entry.addCode(SearchFieldComNameCode, code)
+		}
+		for _, code := range taxon.SciNameCodes {
+			entry.addCode(SearchFieldSciNameCode, code)
+		}
+		s.entries = append(s.entries, entry)
+	}
+	return s
+}
+
+func (e *searchEntry) addName(field, value string) {
+	norm := normalizeSearch(value)
+	if norm == "" {
+		return
+	}
+	e.names = append(e.names, searchName{field: field, value: value, norm: norm, tokens: strings.Fields(norm)})
+}
+
+func (e *searchEntry) addCode(field, value string) {
+	norm := strings.ToLower(strings.TrimSpace(value))
+	if norm == "" {
+		return
+	}
+	e.codes = append(e.codes, searchName{field: field, value: value, norm: norm})
+}
+
+// Search returns the taxa that best match query, best first. Ties are broken
+// by taxonomic order.
+func (s *Searcher) Search(query string, opts ...SearchOption) []SearchResult {
+	o := searchOptions{limit: defaultSearchLimit, minScore: defaultSearchMinScore}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	norm := normalizeSearch(query)
+	if norm == "" {
+		return nil
+	}
+	tokens := strings.Fields(norm)
+	compact := strings.Join(tokens, "")
+
+	var results []SearchResult
+	for _, entry := range s.entries {
+		if len(o.categories) > 0 && !contains(o.categories, entry.taxon.Category) {
+			continue
+		}
+
+		best := SearchResult{Taxon: entry.taxon}
+		for _, code := range entry.codes {
+			if code.norm == compact && best.Score < 0.95 {
+				best.Score, best.Field, best.Matched = 0.95, code.field, code.value
+			}
+		}
+		for _, name := range entry.names {
+			if score := scoreName(norm, tokens, name); score > best.Score {
+				best.Score, best.Field, best.Matched = score, name.field, name.value
+			}
+		}
+		if best.Score >= o.minScore && best.Score > 0 {
+			results = append(results, best)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Taxon.TaxonOrder < results[j].Taxon.TaxonOrder
+	})
+	if o.limit > 0 && len(results) > o.limit {
+		results = results[:o.limit]
+	}
+	return results
+}
+
+// Search is a shorthand for NewSearcher(t).Search. The Searcher is built on
+// first use and reused.
+func (t *Taxonomy) Search(query string, opts ...SearchOption) []SearchResult {
+	t.searcherOnce.Do(func() {
+		t.searcher = NewSearcher(t)
+	})
+	return t.searcher.Search(query, opts...)
+}
+
+// scoreName scores a query against one name. Every query token is matched
+// against a distinct name token; the score is the average token score,
+// reduced slightly for each name token left unmatched.
+func scoreName(norm string, tokens []string, name searchName) float64 {
+	if norm == name.norm {
+		return 1
+	}
+	if strings.HasPrefix(name.norm, norm) {
+		return 0.9
+	}
+
+	used := make([]bool, len(name.tokens))
+	var total float64
+	matched := 0
+	for _, q := range tokens {
+		bestScore, bestIdx := 0.0, -1
+		for i, c := range name.tokens {
+			if used[i] {
+				continue
+			}
+			if score := scoreToken(q, c); score > bestScore {
+				bestScore, bestIdx = score, i
+			}
+		}
+		if bestIdx >= 0 {
+			used[bestIdx] = true
+			matched++
+			total += bestScore
+		}
+	}
+	if matched == 0 {
+		return 0
+	}
+
+	score := total / float64(len(tokens)) * 0.85
+	if unmatched := len(name.tokens) - matched; unmatched > 0 {
+		score -= 0.02 * float64(unmatched)
+	}
+	return score
+}
+
+func scoreToken(q, c string) float64 {
+	switch {
+	case q == c:
+		return 1
+	case strings.HasPrefix(c, q):
+		return 0.9
+	}
+
+	qr, cr := []rune(q), []rune(c)
+	if len(qr) >= 3 {
+		prefix := cr
+		if len(prefix) > len(qr) {
+			prefix = prefix[:len(qr)]
+		}
+		d := levenshtein(qr, cr)
+		if pd := levenshtein(qr, prefix); pd < d {
+			d = pd
+		}
+		if d <= len(qr)/4+1 && d < len(qr)/2+1 {
+			return 0.8 - 0.1*float64(d)
+		}
+	}
+	if len(qr) >= 2 && strings.Contains(c, q) {
+		return 0.7
+	}
+	if len(qr) >= 2 && qr[0] == cr[0] && isSubsequence(qr, cr) {
+		return 0.6
+	}
+	return 0
+}
+
+// normalizeSearch lowercases s and turns everything but letters and digits
+// into spaces, so that "Black-capped" matches "black capped".
+func normalizeSearch(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func isSubsequence(q, c []rune) bool {
+	i := 0
+	for _, r := range c {
+		if i < len(q) && q[i] == r {
+			i++
+		}
+	}
+	return i == len(q)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
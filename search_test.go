@@ -0,0 +1,101 @@
+package ebird
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var searchTaxa = []EbirdTaxon{
+	{SpeciesCode: "bkcchi", ComName: "Black-capped Chickadee", SciName: "Poecile atricapillus", Category: "species", TaxonOrder: 31234, BandingCodes: []string{"BCCH"}, ComNameCodes: []string{"BCCH"}, SciNameCodes: []string{"POAT"}},
+	{SpeciesCode: "carchi", ComName: "Carolina Chickadee", SciName: "Poecile carolinensis", Category: "species", TaxonOrder: 31230, BandingCodes: []string{"CACH"}},
+	{SpeciesCode: "mouchi", ComName: "Mountain Chickadee", SciName: "Poecile gambeli", Category: "species", TaxonOrder: 31240, BandingCodes: []string{"MOCH"}},
+	{SpeciesCode: "bkpwar", ComName: "Blackpoll Warbler", SciName: "Setophaga striata", Category: "species", TaxonOrder: 33000, BandingCodes: []string{"BLPW"}},
+	{SpeciesCode: "bcnher", ComName: "Black-crowned Night Heron", SciName: "Nycticorax nycticorax", Category: "species", TaxonOrder: 5000, BandingCodes: []string{"BCNH"}},
+	{SpeciesCode: "x00659", ComName: "Black-capped x Carolina Chickadee (hybrid)", SciName: "Poecile atricapillus x carolinensis", Category: "hybrid", TaxonOrder: 31235},
+	{SpeciesCode: "chicka1", ComName: "chickadee sp.", SciName: "Poecile sp.", Category: "spuh", TaxonOrder: 31250},
+}
+
+func codesOf(results []SearchResult) []string {
+	codes := make([]string, 0, len(results))
+	for _, r := range results {
+		codes = append(codes, r.Taxon.SpeciesCode)
+	}
+	return codes
+}
+
+func TestSearch(t *testing.T) {
+	tax := NewTaxonomy(searchTaxa)
+
+	tests := []struct {
+		query string
+		want  string
+		field string
+	}{
+		{query: "Black-capped Chickadee", want: "bkcchi", field: SearchFieldComName},
+		{query: "blk capped chick", want: "bkcchi", field: SearchFieldComName},
+		{query: "Parus atricap", want: "bkcchi", field: SearchFieldSciName},
+		{query: "bcch", want: "bkcchi", field: SearchFieldBandingCode},
+		{query: "poat", want: "bkcchi", field: SearchFieldSciNameCode},
+		{query: "carchi", want: "carchi", field: SearchFieldSpeciesCode},
+		{query: "mountian chikadee", want: "mouchi", field: SearchFieldComName},
+		{query: "blackpol", want: "bkpwar", field: SearchFieldComName},
+		{query: "night heron", want: "bcnher", field: SearchFieldComName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results := tax.Search(tt.query)
+			require.NotEmpty(t, results)
+			assert.Equal(t, tt.want, results[0].Taxon.SpeciesCode, "results: %v", codesOf(results))
+			assert.Equal(t, tt.field, results[0].Field)
+			for i := 1; i < len(results); i++ {
+				assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+			}
+		})
+	}
+
+	assert.Empty(t, tax.Search(""))
+	assert.Empty(t, tax.Search("zzzzzz"))
+}
+
+func TestSearchOptions(t *testing.T) {
+	tax := NewTaxonomy(searchTaxa)
+
+	results := tax.Search("chickadee", SearchLimit(0))
+	assert.Equal(t, []string{"chicka1", "carchi", "mouchi", "bkcchi", "x00659"}, codesOf(results))
+
+	results = tax.Search("chickadee", SearchCategories("species"))
+	assert.Equal(t, []string{"carchi", "mouchi", "bkcchi"}, codesOf(results))
+
+	results = tax.Search("chickadee", SearchCategories("spuh", "hybrid"), SearchLimit(1))
+	assert.Equal(t, []string{"chicka1"}, codesOf(results))
+
+	results = tax.Search("chick", SearchMinScore(0.95))
+	assert.Empty(t, results)
+}
+
+func TestSearchLocalized(t *testing.T) {
+	tax := NewTaxonomy(searchTaxa)
+	localized := []EbirdTaxon{
+		{SpeciesCode: "bkcchi", ComName: "Mésange à tête noire"},
+		{SpeciesCode: "carchi", ComName: "Mésange de Caroline"},
+	}
+	searcher := NewSearcher(tax, localized)
+
+	results := searcher.Search("mesange tete noire")
+	require.NotEmpty(t, results)
+	assert.Equal(t, "bkcchi", results[0].Taxon.SpeciesCode)
+
+	results = searcher.Search("mésange de caroline")
+	require.NotEmpty(t, results)
+	assert.Equal(t, "carchi", results[0].Taxon.SpeciesCode)
+	assert.Equal(t, 1.0, results[0].Score)
+
+	results = searcher.Search("mésange à tête")
+	require.NotEmpty(t, results)
+	assert.Equal(t, "bkcchi", results[0].Taxon.SpeciesCode)
+	assert.Equal(t, SearchFieldLocalComName, results[0].Field)
+	assert.Equal(t, "Mésange à tête noire", results[0].Matched)
+}
@@ -0,0 +1,392 @@
+// Package server implements a caching reverse proxy for the eBird API.
+//
+// The proxy serves the same paths as the API under /v2/, such as
+// /v2/data/obs/US-NY/recent, and forwards them through a shared
+// *ebird.Client. The client holds the API key, so consumers can point
+// ebird.WithBaseURL at the proxy without one. Identical requests that arrive
+// while one is in flight share a single upstream call, and successful
+// responses can be cached for a short time on top of the client's own cache.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+)
+
+const (
+	// PathPrefix is the path the API paths are served under.
+	PathPrefix = "/v2/"
+
+	defaultUpstreamTimeout = 30 * time.Second
+
+	// minConsumerIdle is the shortest time a consumer's rate limiter is kept
+	// after its last request.
+	minConsumerIdle = time.Minute
+)
+
+// Server is an http.Handler that proxies eBird API requests. It also serves
+// /healthz and /stats.
+type Server struct {
+	client          *ebird.Client
+	cache           ebird.Cache
+	cacheTTL        time.Duration
+	consumerRate    float64
+	consumerBurst   int
+	upstreamTimeout time.Duration
+	now             func() time.Time
+	started         time.Time
+
+	mu        sync.Mutex
+	inflight  map[string]*call
+	consumers map[string]*consumer
+	lastSweep time.Time
+
+	stats counters
+}
+
+type consumer struct {
+	limiter  *ebird.RateLimiter
+	lastSeen time.Time
+}
+
+type call struct {
+	done   chan struct{}
+	body   []byte
+	header http.Header
+	err    error
+}
+
+type counters struct {
+	requests       atomic.Int64
+	cacheHits      atomic.Int64
+	coalesced      atomic.Int64
+	upstream       atomic.Int64
+	upstreamErrors atomic.Int64
+	rateLimited    atomic.Int64
+}
+
+// Stats is the body of the /stats endpoint.
+type Stats struct {
+	Requests       int64   `json:"requests"`
+	CacheHits      int64   `json:"cacheHits"`
+	Coalesced      int64   `json:"coalesced"`
+	Upstream       int64   `json:"upstream"`
+	UpstreamErrors int64   `json:"upstreamErrors"`
+	RateLimited    int64   `json:"rateLimited"`
+	InFlight       int     `json:"inFlight"`
+	UptimeSeconds  float64 `json:"uptimeSeconds"`
+}
+
+type Option func(*Server)
+
+// WithCache caches every successful response for ttl, keyed on the path,
+// query string and Accept-Language header. The client's own cache still applies to reference data.
+func WithCache(cache ebird.Cache, ttl time.Duration) Option {
+	return func(s *Server) {
+		s.cache = cache
+		s.cacheTTL = ttl
+	}
+}
+
+// WithConsumerRateLimit limits each remote address to requestsPerSecond,
+// with bursts of up to burst. Requests over the limit get a 429 response.
+// Cached responses count towards the limit too. Limiters of addresses that
+// have been idle long enough to refill their burst are dropped.
+func WithConsumerRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(s *Server) {
+		s.consumerRate = requestsPerSecond
+		s.consumerBurst = burst
+	}
+}
+
+// WithUpstreamTimeout bounds each upstream call, including its retries. The
+// call is not tied to the request that started it, because coalesced
+// requests wait on it too. The default is 30 seconds.
+func WithUpstreamTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		if timeout > 0 {
+			s.upstreamTimeout = timeout
+		}
+	}
+}
+
+// New returns a Server that forwards requests through client. Configure
+// upstream rate limits, retries and reference data caching on the client.
+func New(client *ebird.Client, opts ...Option) *Server {
+	s := &Server{
+		client:          client,
+		upstreamTimeout: defaultUpstreamTimeout,
+		now:             time.Now,
+		inflight:        make(map[string]*call),
+		consumers:       make(map[string]*consumer),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.started = s.now()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/healthz":
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		return
+	case "/stats":
+		writeJSON(w, http.StatusOK, s.Stats())
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed", 0)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	if path == r.URL.Path {
+		writeError(w, http.StatusNotFound, "not found", 0)
+		return
+	}
+	if _, ok := ebird.EndpointName(path); !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown endpoint %q", path), 0)
+		return
+	}
+
+	s.stats.requests.Add(1)
+	if !s.allow(r) {
+		s.stats.rateLimited.Add(1)
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded", time.Second)
+		return
+	}
+
+	query := r.URL.Query()
+	// The key is injected by the client; never forward a caller's own.
+	query.Del("key")
+	lang := r.Header.Get("Accept-Language")
+	key := path + "?" + query.Encode() + "\x00" + lang
+
+	if s.cache != nil {
+		if body, ok := s.cache.Get(key); ok {
+			s.stats.cacheHits.Add(1)
+			writeBody(w, r, path, query, body, "HIT")
+			return
+		}
+	}
+
+	c, leader := s.join(key)
+	if leader {
+		s.forward(c, key, path, query, lang)
+	} else {
+		s.stats.coalesced.Add(1)
+	}
+
+	select {
+	case <-c.done:
+	case <-r.Context().Done():
+		return
+	}
+
+	if c.err != nil {
+		writeUpstreamError(w, c.err)
+		return
+	}
+	writeBody(w, r, path, query, c.body, "MISS")
+}
+
+// Stats returns the request counters.
+func (s *Server) Stats() Stats {
+	s.mu.Lock()
+	inFlight := len(s.inflight)
+	s.mu.Unlock()
+
+	return Stats{
+		Requests:       s.stats.requests.Load(),
+		CacheHits:      s.stats.cacheHits.Load(),
+		Coalesced:      s.stats.coalesced.Load(),
+		Upstream:       s.stats.upstream.Load(),
+		UpstreamErrors: s.stats.upstreamErrors.Load(),
+		RateLimited:    s.stats.rateLimited.Load(),
+		InFlight:       inFlight,
+		UptimeSeconds:  s.now().Sub(s.started).Seconds(),
+	}
+}
+
+// join returns the in-flight call for key, creating it if there is none. The
+// boolean reports whether the caller created it and must run it.
+func (s *Server) join(key string) (*call, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.inflight[key]; ok {
+		return c, false
+	}
+	c := &call{done: make(chan struct{})}
+	s.inflight[key] = c
+	return c, true
+}
+
+// forward starts the upstream call for c in the background, so that it
+// completes and fills the cache even if the leading request goes away. A
+// non-empty lang is sent as the Accept-Language header.
+func (s *Server) forward(c *call, key, path string, query url.Values, lang string) {
+	s.stats.upstream.Add(1)
+	opts := []ebird.RequestOption{ebird.Params(query)}
+	if lang != "" {
+		opts = append(opts, ebird.AcceptLanguage(lang))
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.upstreamTimeout)
+		defer cancel()
+
+		c.body, c.err = s.client.Raw(ctx, path, opts...)
+		if c.err != nil {
+			s.stats.upstreamErrors.Add(1)
+		} else if s.cache != nil && s.cacheTTL > 0 && c.body != nil {
+			s.cache.Set(key, c.body, s.cacheTTL)
+		}
+
+		s.mu.Lock()
+		delete(s.inflight, key)
+		s.mu.Unlock()
+		close(c.done)
+	}()
+}
+
+func (s *Server) allow(r *http.Request) bool {
+	if s.consumerRate <= 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.sweepConsumers(now)
+	cons, ok := s.consumers[host]
+	if !ok {
+		cons = &consumer{limiter: ebird.NewRateLimiter(s.consumerRate, s.consumerBurst)}
+		s.consumers[host] = cons
+	}
+	cons.lastSeen = now
+	s.mu.Unlock()
+
+	return cons.limiter.Allow()
+}
+
+// sweepConsumers drops the rate limiters of consumers that have been idle
+// for longer than it takes to refill a full burst, since a new limiter
+// behaves the same. It runs at most once per idle period. The caller holds
+// s.mu.
+func (s *Server) sweepConsumers(now time.Time) {
+	idle := s.consumerIdle()
+	if now.Sub(s.lastSweep) < idle {
+		return
+	}
+	s.lastSweep = now
+	for host, cons := range s.consumers {
+		if now.Sub(cons.lastSeen) > idle {
+			delete(s.consumers, host)
+		}
+	}
+}
+
+func (s *Server) consumerIdle() time.Duration {
+	burst := s.consumerBurst
+	if burst < 1 {
+		burst = 1
+	}
+	idle := time.Duration(float64(burst) / s.consumerRate * float64(time.Second))
+	if idle < minConsumerIdle {
+		return minConsumerIdle
+	}
+	return idle
+}
+
+// csvEndpoints are the endpoints that return CSV unless asked for fmt=json.
+var csvEndpoints = map[string]bool{
+	"HotspotsInRegion": true,
+	"NearbyHotspots":   true,
+	"EbirdTaxonomy":    true,
+}
+
+func writeBody(w http.ResponseWriter, r *http.Request, path string, query url.Values, body []byte, cacheStatus string) {
+	w.Header().Set("X-Cache", cacheStatus)
+	if body == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(path, query))
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// contentType returns the type of the upstream body for path, which is CSV
+// when requested with fmt=csv or when the endpoint returns CSV by default.
+func contentType(path string, query url.Values) string {
+	format := query.Get("fmt")
+	if format == "" {
+		if name, _ := ebird.EndpointName(path); csvEndpoints[name] {
+			format = "csv"
+		}
+	}
+	if format == "csv" {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// writeUpstreamError relays an API error with its original status, and maps
+// other failures to 400 or 502.
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	var apiErr ebird.Error
+	switch {
+	case errors.As(err, &apiErr):
+		writeError(w, apiErr.Status, apiErr.Message, apiErr.RetryAfter)
+	case errors.Is(err, ebird.ErrInvalidArgument):
+		writeError(w, http.StatusBadRequest, err.Error(), 0)
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, "upstream request timed out", 0)
+	default:
+		writeError(w, http.StatusBadGateway, err.Error(), 0)
+	}
+}
+
+// writeError writes an error in the same shape as the eBird API, so that
+// clients decode the message the same way.
+func writeError(w http.ResponseWriter, status int, message string, retryAfter time.Duration) {
+	if retryAfter > 0 {
+		seconds := int((retryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"status": strconv.Itoa(status),
+			"title":  message,
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
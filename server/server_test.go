@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/siansiansu/go-ebird"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProxy(t *testing.T, upstream http.HandlerFunc, opts ...Option) (*Server, *httptest.Server) {
+	t.Helper()
+	api := httptest.NewServer(upstream)
+	t.Cleanup(api.Close)
+
+	client, err := ebird.NewClient("server-key", ebird.WithBaseURL(api.URL+"/"))
+	require.NoError(t, err)
+
+	s := New(client, opts...)
+	proxy := httptest.NewServer(s)
+	t.Cleanup(proxy.Close)
+	return s, proxy
+}
+
+func TestServerForwardsWithKey(t *testing.T) {
+	_, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "server-key", r.Header.Get("X-eBirdApiToken"))
+		assert.Equal(t, "/data/obs/US-NY/recent", r.URL.Path)
+		assert.Equal(t, "back=3", r.URL.RawQuery)
+		w.Write([]byte(`[{"speciesCode":"amecro","comName":"American Crow"}]`))
+	})
+
+	client, err := ebird.NewClient("unused", ebird.WithBaseURL(proxy.URL+PathPrefix))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, proxy.URL+"/v2/data/obs/US-NY/recent?back=3&key=caller-key", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-eBirdApiToken", "caller-key")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	obs, err := client.RecentObservationsInRegion(context.Background(), "US-NY", ebird.Back(3))
+	require.NoError(t, err)
+	require.Len(t, obs, 1)
+	assert.Equal(t, "American Crow", obs[0].ComName)
+}
+
+func TestServerCache(t *testing.T) {
+	var upstream int32
+	s, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		w.Write([]byte(`[]`))
+	}, WithCache(ebird.NewMemoryCache(0), time.Minute))
+
+	for i, want := range []string{"MISS", "HIT", "HIT"} {
+		resp, err := http.Get(proxy.URL + "/v2/data/obs/US-NY/recent")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.Header.Get("X-Cache"), "request %d", i)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream))
+	stats := s.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(2), stats.CacheHits)
+	assert.Equal(t, int64(1), stats.Upstream)
+}
+
+func TestServerCoalescesRequests(t *testing.T) {
+	var upstream int32
+	release := make(chan struct{})
+	s, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		<-release
+		w.Write([]byte(`["amecro"]`))
+	})
+
+	const n = 5
+	var wg sync.WaitGroup
+	bodies := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := http.Get(proxy.URL + "/v2/product/spplist/US-NY")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+		}(i)
+	}
+
+	require.Eventually(t, func() bool { return s.Stats().Requests == n }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream))
+	assert.Equal(t, int64(n-1), s.Stats().Coalesced)
+	for _, body := range bodies {
+		assert.Equal(t, `["amecro"]`, body)
+	}
+}
+
+func TestServerRelaysErrors(t *testing.T) {
+	_, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"errors":[{"title":"Too many requests"}]}`))
+	})
+
+	client, err := ebird.NewClient("unused", ebird.WithBaseURL(proxy.URL+PathPrefix))
+	require.NoError(t, err)
+
+	_, err = client.RegionInfo(context.Background(), "US-NY")
+	assert.ErrorIs(t, err, ebird.ErrRateLimited)
+	var apiErr ebird.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Too many requests", apiErr.Message)
+	assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+}
+
+func TestServerRoutes(t *testing.T) {
+	s, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}, WithConsumerRateLimit(0.001, 1))
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/healthz", http.StatusOK},
+		{"/v2/ref/unknown/thing/here", http.StatusNotFound},
+		{"/data/obs/US-NY/recent", http.StatusNotFound},
+		{"/v2/ref/hotspot/US-NY", http.StatusOK},
+		{"/v2/ref/hotspot/US-NY", http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(proxy.URL + tt.path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, tt.status, resp.StatusCode, tt.path)
+	}
+
+	resp, err := http.Get(proxy.URL + "/stats")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var stats Stats
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	assert.Equal(t, int64(1), stats.RateLimited)
+	assert.Equal(t, s.Stats().Requests, stats.Requests)
+}
+
+func TestServerAcceptLanguage(t *testing.T) {
+	var upstream int32
+	_, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		if r.Header.Get("Accept-Language") == "fr" {
+			w.Write([]byte(`[{"speciesCode":"amecro","comName":"Corneille d'Amérique"}]`))
+			return
+		}
+		w.Write([]byte(`[{"speciesCode":"amecro","comName":"American Crow"}]`))
+	}, WithCache(ebird.NewMemoryCache(0), time.Minute))
+
+	french, err := ebird.NewClient("unused", ebird.WithBaseURL(proxy.URL+PathPrefix), ebird.WithAcceptLanguage("fr"))
+	require.NoError(t, err)
+	english, err := ebird.NewClient("unused", ebird.WithBaseURL(proxy.URL+PathPrefix))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		obs, err := french.RecentObservationsInRegion(context.Background(), "US-NY")
+		require.NoError(t, err)
+		assert.Equal(t, "Corneille d'Amérique", obs[0].ComName)
+
+		obs, err = english.RecentObservationsInRegion(context.Background(), "US-NY")
+		require.NoError(t, err)
+		assert.Equal(t, "American Crow", obs[0].ComName)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&upstream))
+}
+
+func TestServerEvictsIdleConsumers(t *testing.T) {
+	s, _ := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}, WithConsumerRateLimit(10, 5))
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	for _, addr := range []string{"192.0.2.1:1000", "192.0.2.2:1000", "192.0.2.3:1000"} {
+		req := httptest.NewRequest(http.MethodGet, "/v2/ref/hotspot/US-NY", nil)
+		req.RemoteAddr = addr
+		s.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	assert.Len(t, s.consumers, 3)
+
+	now = now.Add(2 * minConsumerIdle)
+	req := httptest.NewRequest(http.MethodGet, "/v2/ref/hotspot/US-NY", nil)
+	req.RemoteAddr = "192.0.2.1:1000"
+	s.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, s.consumers, 1)
+}
+
+func TestServerContentType(t *testing.T) {
+	_, proxy := newProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`x`))
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v2/data/obs/US-NY/recent", "application/json; charset=utf-8"},
+		{"/v2/ref/hotspot/US-NY", "text/csv; charset=utf-8"},
+		{"/v2/ref/hotspot/US-NY?fmt=json", "application/json; charset=utf-8"},
+		{"/v2/ref/hotspot/geo?lat=42&lng=-76", "text/csv; charset=utf-8"},
+		{"/v2/ref/taxonomy/ebird", "text/csv; charset=utf-8"},
+		{"/v2/ref/taxonomy/ebird?fmt=json", "application/json; charset=utf-8"},
+		{"/v2/ref/region/list/subnational1/US?fmt=csv", "text/csv; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(proxy.URL + tt.path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, tt.want, resp.Header.Get("Content-Type"), tt.path)
+	}
+}
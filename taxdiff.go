@@ -0,0 +1,383 @@
+package ebird
+
+import (
+	"sort"
+	"strings"
+)
+
+// TaxonomyDiff describes what changed between two taxonomy snapshots, such
+// as two versions fetched with the Version option. Splits and lumps are
+// inferred from shared scientific names within a family and are best
+// treated as suggestions.
+type TaxonomyDiff struct {
+	Added           []EbirdTaxon
+	Removed         []EbirdTaxon
+	CodeChanges     []CodeChange
+	NameChanges     []NameChange
+	CategoryChanges []CategoryChange
+	Reordered       []OrderChange
+	Splits          []Split
+	Lumps           []Lump
+}
+
+// CodeChange is a taxon that kept its name but got a new species code.
+type CodeChange struct {
+	OldCode string
+	NewCode string
+	SciName string
+}
+
+type NameChange struct {
+	SpeciesCode string
+	OldComName  string
+	NewComName  string
+	OldSciName  string
+	NewSciName  string
+}
+
+type CategoryChange struct {
+	SpeciesCode string
+	OldCategory string
+	NewCategory string
+}
+
+// OrderChange is a taxon that moved relative to the taxa around it. Changes
+// to TaxonOrder that keep the sequence intact are not reported.
+type OrderChange struct {
+	SpeciesCode   string
+	OldTaxonOrder float64
+	NewTaxonOrder float64
+}
+
+// Split is an old species whose range of names is now covered by several
+// species. From may also appear in Into when the old code was kept for one
+// of the daughter species.
+type Split struct {
+	From string
+	Into []string
+}
+
+// Lump is a set of old species now treated as one. Into may also appear in
+// From when one of the old codes was kept.
+type Lump struct {
+	From []string
+	Into string
+}
+
+// DiffTaxonomies compares an older taxonomy snapshot with a newer one,
+// matching taxa by species code.
+func DiffTaxonomies(before, after []EbirdTaxon) *TaxonomyDiff {
+	oldTax, newTax := NewTaxonomy(before), NewTaxonomy(after)
+	d := &TaxonomyDiff{}
+
+	var added, removed []EbirdTaxon
+	for _, taxon := range newTax.taxa {
+		if _, ok := oldTax.byCode[taxon.SpeciesCode]; !ok {
+			added = append(added, taxon)
+		}
+	}
+	for _, taxon := range oldTax.taxa {
+		n, ok := newTax.ByCode(taxon.SpeciesCode)
+		if !ok {
+			removed = append(removed, taxon)
+			continue
+		}
+		if taxon.ComName != n.ComName || taxon.SciName != n.SciName {
+			d.NameChanges = append(d.NameChanges, NameChange{
+				SpeciesCode: taxon.SpeciesCode,
+				OldComName:  taxon.ComName,
+				NewComName:  n.ComName,
+				OldSciName:  taxon.SciName,
+				NewSciName:  n.SciName,
+			})
+		}
+		if taxon.Category != n.Category {
+			d.CategoryChanges = append(d.CategoryChanges, CategoryChange{
+				SpeciesCode: taxon.SpeciesCode,
+				OldCategory: taxon.Category,
+				NewCategory: n.Category,
+			})
+		}
+	}
+
+	// A removed and an added taxon with the same scientific name are the same
+	// taxon under a new code.
+	addedBySciName := make(map[string]int)
+	for i, taxon := range added {
+		if key := normalizeName(taxon.SciName); key != "" {
+			addedBySciName[key] = i
+		}
+	}
+	renamed := make(map[string]bool)
+	for _, taxon := range removed {
+		i, ok := addedBySciName[normalizeName(taxon.SciName)]
+		if !ok || renamed[added[i].SpeciesCode] {
+			continue
+		}
+		d.CodeChanges = append(d.CodeChanges, CodeChange{OldCode: taxon.SpeciesCode, NewCode: added[i].SpeciesCode, SciName: added[i].SciName})
+		renamed[taxon.SpeciesCode] = true
+		renamed[added[i].SpeciesCode] = true
+	}
+	for _, taxon := range added {
+		if !renamed[taxon.SpeciesCode] {
+			d.Added = append(d.Added, taxon)
+		}
+	}
+	for _, taxon := range removed {
+		if !renamed[taxon.SpeciesCode] {
+			d.Removed = append(d.Removed, taxon)
+		}
+	}
+
+	d.Reordered = reorderedTaxa(oldTax, newTax)
+	d.Splits = findSplits(oldTax, newTax, renamed)
+	d.Lumps = findLumps(oldTax, newTax, renamed)
+	return d
+}
+
+// Empty reports whether the snapshots have no differences.
+func (d *TaxonomyDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.CodeChanges) == 0 &&
+		len(d.NameChanges) == 0 && len(d.CategoryChanges) == 0 && len(d.Reordered) == 0
+}
+
+// Remap translates species codes from the old snapshot to the new one.
+// Renamed codes and codes lumped into another species are replaced, and
+// duplicates are dropped. Codes that were removed without a single
+// replacement, including species split under new codes, are returned in
+// unresolved and left out of remapped.
+func (d *TaxonomyDiff) Remap(codes []string) (remapped []string, unresolved []string) {
+	replace := make(map[string]string)
+	for _, c := range d.CodeChanges {
+		replace[c.OldCode] = c.NewCode
+	}
+	for _, l := range d.Lumps {
+		for _, from := range l.From {
+			replace[from] = l.Into
+		}
+	}
+	gone := make(map[string]bool)
+	for _, taxon := range d.Removed {
+		if _, ok := replace[taxon.SpeciesCode]; !ok {
+			gone[taxon.SpeciesCode] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if gone[code] {
+			unresolved = append(unresolved, code)
+			continue
+		}
+		if to, ok := replace[code]; ok {
+			code = to
+		}
+		if !seen[code] {
+			seen[code] = true
+			remapped = append(remapped, code)
+		}
+	}
+	return remapped, unresolved
+}
+
+// reorderedTaxa returns the taxa present in both snapshots that are not
+// part of the longest run kept in the same relative order, which is the
+// smallest set of moves that explains the new sequence.
+func reorderedTaxa(oldTax, newTax *Taxonomy) []OrderChange {
+	var common []EbirdTaxon
+	var positions []int
+	for _, taxon := range newTax.taxa {
+		if i, ok := oldTax.byCode[taxon.SpeciesCode]; ok {
+			common = append(common, taxon)
+			positions = append(positions, i)
+		}
+	}
+
+	kept := longestIncreasing(positions)
+	var changes []OrderChange
+	for i, taxon := range common {
+		if !kept[i] {
+			changes = append(changes, OrderChange{
+				SpeciesCode:   taxon.SpeciesCode,
+				OldTaxonOrder: oldTax.taxa[positions[i]].TaxonOrder,
+				NewTaxonOrder: taxon.TaxonOrder,
+			})
+		}
+	}
+	return changes
+}
+
+// longestIncreasing marks the elements of one longest strictly increasing
+// subsequence of values.
+func longestIncreasing(values []int) []bool {
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		j := sort.Search(len(tails), func(k int) bool { return values[tails[k]] >= v })
+		if j > 0 {
+			prev[i] = tails[j-1]
+		} else {
+			prev[i] = -1
+		}
+		if j == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[j] = i
+		}
+	}
+
+	kept := make([]bool, len(values))
+	if len(tails) == 0 {
+		return kept
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		kept[i] = true
+	}
+	return kept
+}
+
+// epithets returns the lower-cased words of a scientific name after the
+// genus, ignoring hybrid, "sp." and "Group" markers.
+func epithets(sciName string) []string {
+	words := strings.Fields(strings.ToLower(sciName))
+	if len(words) < 2 {
+		return nil
+	}
+	var out []string
+	for _, w := range words[1:] {
+		if w = strings.Trim(w, "().[]/"); isEpithet(w) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func isEpithet(w string) bool {
+	switch w {
+	case "", "x", "sp", "group", "type":
+		return false
+	}
+	return true
+}
+
+// related reports whether two taxa are in the same family and share a
+// scientific name epithet.
+func related(a, b EbirdTaxon) bool {
+	if a.FamilyCode == "" || a.FamilyCode != b.FamilyCode {
+		return false
+	}
+	for _, x := range epithets(a.SciName) {
+		for _, y := range epithets(b.SciName) {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// subspeciesEpithets maps each binomial in a taxonomy to the epithets used
+// below species level, such as "hudsonicus" for a group named
+// "Poecile atricapillus hudsonicus".
+func subspeciesEpithets(tax *Taxonomy) map[string]map[string]bool {
+	out := make(map[string]map[string]bool)
+	for _, taxon := range tax.taxa {
+		words := strings.Fields(normalizeName(taxon.SciName))
+		if taxon.Category == "species" || len(words) < 3 {
+			continue
+		}
+		binomial := words[0] + " " + words[1]
+		if out[binomial] == nil {
+			out[binomial] = make(map[string]bool)
+		}
+		for _, w := range words[2:] {
+			if w = strings.Trim(w, "().[]/"); isEpithet(w) {
+				out[binomial][w] = true
+			}
+		}
+	}
+	return out
+}
+
+// findSplits looks for old species whose name now spreads over several new
+// species, at least one of which is new. A new species qualifies if it is
+// in the same family and shares an epithet with the old species or with one
+// of its old subspecies groups.
+func findSplits(oldTax, newTax *Taxonomy, renamed map[string]bool) []Split {
+	subspecies := subspeciesEpithets(oldTax)
+
+	var splits []Split
+	for _, o := range oldTax.taxa {
+		if o.Category != "species" {
+			continue
+		}
+
+		var into []string
+		hasNew := false
+		for _, i := range newTax.families[o.FamilyCode] {
+			n := newTax.taxa[i]
+			if n.Category != "species" {
+				continue
+			}
+			isSame := n.SpeciesCode == o.SpeciesCode
+			old, existed := oldTax.ByCode(n.SpeciesCode)
+			isNew := (!existed || old.Category != "species") && !renamed[n.SpeciesCode]
+			if !isSame && !isNew {
+				continue
+			}
+			if isSame || related(o, n) || sharesEpithet(n, subspecies[normalizeName(o.SciName)]) {
+				into = append(into, n.SpeciesCode)
+				hasNew = hasNew || isNew
+			}
+		}
+		if len(into) > 1 && hasNew {
+			splits = append(splits, Split{From: o.SpeciesCode, Into: into})
+		}
+	}
+	return splits
+}
+
+// findLumps is the reverse of findSplits: new species whose name covers
+// several old species, at least one of which was removed.
+func findLumps(oldTax, newTax *Taxonomy, renamed map[string]bool) []Lump {
+	subspecies := subspeciesEpithets(newTax)
+
+	var lumps []Lump
+	for _, n := range newTax.taxa {
+		if n.Category != "species" {
+			continue
+		}
+
+		var from []string
+		hasRemoved := false
+		for _, i := range oldTax.families[n.FamilyCode] {
+			o := oldTax.taxa[i]
+			if o.Category != "species" {
+				continue
+			}
+			isSame := o.SpeciesCode == n.SpeciesCode
+			now, exists := newTax.ByCode(o.SpeciesCode)
+			isRemoved := (!exists || now.Category != "species") && !renamed[o.SpeciesCode]
+			if !isSame && !isRemoved {
+				continue
+			}
+			if isSame || related(o, n) || sharesEpithet(o, subspecies[normalizeName(n.SciName)]) {
+				from = append(from, o.SpeciesCode)
+				hasRemoved = hasRemoved || isRemoved
+			}
+		}
+		if len(from) > 1 && hasRemoved {
+			lumps = append(lumps, Lump{From: from, Into: n.SpeciesCode})
+		}
+	}
+	return lumps
+}
+
+func sharesEpithet(taxon EbirdTaxon, names map[string]bool) bool {
+	for _, e := range epithets(taxon.SciName) {
+		if names[e] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package ebird
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTaxonomies(t *testing.T) {
+	before := []EbirdTaxon{
+		{SpeciesCode: "ostric2", ComName: "Common Ostrich", SciName: "Struthio camelus", Category: "species", TaxonOrder: 1, FamilyCode: "struth1"},
+		{SpeciesCode: "rocpig", ComName: "Rock Pigeon", SciName: "Columba livia", Category: "species", TaxonOrder: 5, FamilyCode: "columb2"},
+		{SpeciesCode: "bkcchi", ComName: "Black-capped Chickadee", SciName: "Poecile atricapillus", Category: "species", TaxonOrder: 10, FamilyCode: "parida1"},
+		{SpeciesCode: "bkcchi1", ComName: "Black-capped Chickadee (hudsonicus)", SciName: "Poecile atricapillus hudsonicus", Category: "issf", TaxonOrder: 11, FamilyCode: "parida1"},
+		{SpeciesCode: "carchi", ComName: "Carolina Chickadee", SciName: "Poecile carolinensis", Category: "species", TaxonOrder: 12, FamilyCode: "parida1"},
+		{SpeciesCode: "tuftit", ComName: "Tufted Titmouse", SciName: "Baeolophus bicolor", Category: "species", TaxonOrder: 20, FamilyCode: "parida1"},
+		{SpeciesCode: "bkctit", ComName: "Black-crested Titmouse", SciName: "Baeolophus atricristatus", Category: "species", TaxonOrder: 21, FamilyCode: "parida1"},
+		{SpeciesCode: "gryjay", ComName: "Gray Jay", SciName: "Perisoreus canadensis", Category: "species", TaxonOrder: 30, FamilyCode: "corvid1"},
+		{SpeciesCode: "oldcod", ComName: "Some Bird", SciName: "Aus bus", Category: "species", TaxonOrder: 40, FamilyCode: "fam1"},
+	}
+	after := []EbirdTaxon{
+		{SpeciesCode: "ostric2", ComName: "Common Ostrich", SciName: "Struthio camelus", Category: "species", TaxonOrder: 2, FamilyCode: "struth1"},
+		{SpeciesCode: "rocpig", ComName: "Rock Pigeon", SciName: "Columba livia", Category: "domestic", TaxonOrder: 5, FamilyCode: "columb2"},
+		{SpeciesCode: "carchi", ComName: "Carolina Chickadee", SciName: "Poecile carolinensis", Category: "species", TaxonOrder: 10, FamilyCode: "parida1"},
+		{SpeciesCode: "bkcchi", ComName: "Black-capped Chickadee", SciName: "Poecile atricapillus", Category: "species", TaxonOrder: 11, FamilyCode: "parida1"},
+		{SpeciesCode: "hudchi", ComName: "Hudsonian Black-capped Chickadee", SciName: "Poecile hudsonicus", Category: "species", TaxonOrder: 12, FamilyCode: "parida1"},
+		{SpeciesCode: "tuftit", ComName: "Tufted Titmouse", SciName: "Baeolophus bicolor", Category: "species", TaxonOrder: 20, FamilyCode: "parida1"},
+		{SpeciesCode: "tuftit1", ComName: "Tufted Titmouse (Black-crested)", SciName: "Baeolophus bicolor atricristatus", Category: "issf", TaxonOrder: 21, FamilyCode: "parida1"},
+		{SpeciesCode: "gryjay", ComName: "Canada Jay", SciName: "Perisoreus canadensis", Category: "species", TaxonOrder: 30, FamilyCode: "corvid1"},
+		{SpeciesCode: "newcod", ComName: "Some Bird", SciName: "Aus bus", Category: "species", TaxonOrder: 40, FamilyCode: "fam1"},
+	}
+
+	d := DiffTaxonomies(before, after)
+	assert.False(t, d.Empty())
+
+	assert.Equal(t, []string{"hudchi", "tuftit1"}, taxonCodes(d.Added))
+	assert.Equal(t, []string{"bkcchi1", "bkctit"}, taxonCodes(d.Removed))
+	assert.Equal(t, []CodeChange{{OldCode: "oldcod", NewCode: "newcod", SciName: "Aus bus"}}, d.CodeChanges)
+	assert.Equal(t, []NameChange{{SpeciesCode: "gryjay", OldComName: "Gray Jay", NewComName: "Canada Jay", OldSciName: "Perisoreus canadensis", NewSciName: "Perisoreus canadensis"}}, d.NameChanges)
+	assert.Equal(t, []CategoryChange{{SpeciesCode: "rocpig", OldCategory: "species", NewCategory: "domestic"}}, d.CategoryChanges)
+	assert.Equal(t, []OrderChange{{SpeciesCode: "carchi", OldTaxonOrder: 12, NewTaxonOrder: 10}}, d.Reordered)
+	assert.Equal(t, []Split{{From: "bkcchi", Into: []string{"bkcchi", "hudchi"}}}, d.Splits)
+	assert.Equal(t, []Lump{{From: []string{"tuftit", "bkctit"}, Into: "tuftit"}}, d.Lumps)
+
+	remapped, unresolved := d.Remap([]string{"oldcod", "bkctit", "tuftit", "bkcchi1", "bkcchi", "unknown"})
+	assert.Equal(t, []string{"newcod", "tuftit", "bkcchi", "unknown"}, remapped)
+	assert.Equal(t, []string{"bkcchi1"}, unresolved)
+}
+
+func TestDiffTaxonomiesUnchanged(t *testing.T) {
+	d := DiffTaxonomies(testTaxa, testTaxa)
+	assert.True(t, d.Empty())
+	assert.Empty(t, d.Splits)
+	assert.Empty(t, d.Lumps)
+}
+
+func taxonCodes(taxa []EbirdTaxon) []string {
+	codes := make([]string, 0, len(taxa))
+	for _, taxon := range taxa {
+		codes = append(codes, taxon.SpeciesCode)
+	}
+	return codes
+}
@@ -0,0 +1,241 @@
+package ebird
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Taxonomy is an in-memory index over the eBird taxonomy. Taxa are kept in
+// taxonomic order and can be looked up by species code, scientific name,
+// common name or banding code.
+type Taxonomy struct {
+	taxa []EbirdTaxon
+
+	byCode    map[string]int
+	bySciName map[string]int
+	byComName map[string]int
+	byBanding map[string]int
+	families  map[string][]int
+	orders    map[string][]int
+
+	familyCodes []string
+	orderNames  []string
+
+	searcherOnce sync.Once
+	searcher     *Searcher
+}
+
+// NewTaxonomy builds an index from taxa, such as the result of
+// EbirdTaxonomy. The slice is copied; if a species code appears more than
+// once the first occurrence wins.
+func NewTaxonomy(taxa []EbirdTaxon) *Taxonomy {
+	sorted := make([]EbirdTaxon, len(taxa))
+	copy(sorted, taxa)
+	SortTaxa(sorted)
+
+	t := &Taxonomy{
+		taxa:      make([]EbirdTaxon, 0, len(sorted)),
+		byCode:    make(map[string]int, len(sorted)),
+		bySciName: make(map[string]int, len(sorted)),
+		byComName: make(map[string]int, len(sorted)),
+		byBanding: make(map[string]int),
+		families:  make(map[string][]int),
+		orders:    make(map[string][]int),
+	}
+
+	for _, taxon := range sorted {
+		if _, ok := t.byCode[taxon.SpeciesCode]; ok || taxon.SpeciesCode == "" {
+			continue
+		}
+		i := len(t.taxa)
+		t.taxa = append(t.taxa, taxon)
+		t.byCode[taxon.SpeciesCode] = i
+
+		addName(t.bySciName, taxon.SciName, i)
+		addName(t.byComName, taxon.ComName, i)
+		for _, code := range taxon.BandingCodes {
+			addName(t.byBanding, code, i)
+		}
+
+		if taxon.FamilyCode != "" {
+			if _, ok := t.families[taxon.FamilyCode]; !ok {
+				t.familyCodes = append(t.familyCodes, taxon.FamilyCode)
+			}
+			t.families[taxon.FamilyCode] = append(t.families[taxon.FamilyCode], i)
+		}
+		if taxon.Order != "" {
+			if _, ok := t.orders[taxon.Order]; !ok {
+				t.orderNames = append(t.orderNames, taxon.Order)
+			}
+			t.orders[taxon.Order] = append(t.orders[taxon.Order], i)
+		}
+	}
+	return t
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func addName(index map[string]int, name string, i int) {
+	key := normalizeName(name)
+	if key == "" {
+		return
+	}
+	if _, ok := index[key]; !ok {
+		index[key] = i
+	}
+}
+
+// LoadTaxonomy fetches the taxonomy with EbirdTaxonomy and indexes it.
+// Request options such as Locale, Version and Species are passed
+// through.
+func (c *Client) LoadTaxonomy(ctx context.Context, opts ...RequestOption) (*Taxonomy, error) {
+	taxa, err := c.EbirdTaxonomy(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaxonomy(taxa), nil
+}
+
+// ReadTaxonomyJSON indexes a taxonomy stored in the JSON format returned by
+// the API, such as one written with WriteJSON.
+func ReadTaxonomyJSON(r io.Reader) (*Taxonomy, error) {
+	var taxa []EbirdTaxon
+	if err := json.NewDecoder(r).Decode(&taxa); err != nil {
+		return nil, fmt.Errorf("failed to decode taxonomy: %w", err)
+	}
+	return NewTaxonomy(taxa), nil
+}
+
+// ReadTaxonomyCSV indexes a taxonomy stored in the CSV export format.
+func ReadTaxonomyCSV(r io.Reader) (*Taxonomy, error) {
+	taxa, err := parseTaxonomyCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode taxonomy CSV: %w", err)
+	}
+	return NewTaxonomy(taxa), nil
+}
+
+// LoadTaxonomyFile reads a taxonomy from a file. Files ending in .csv are
+// read as the CSV export, anything else as JSON.
+func LoadTaxonomyFile(path string) (*Taxonomy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open taxonomy file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ReadTaxonomyCSV(f)
+	}
+	return ReadTaxonomyJSON(f)
+}
+
+// WriteJSON writes the taxa in the API's JSON format so that they can be
+// loaded again with ReadTaxonomyJSON.
+func (t *Taxonomy) WriteJSON(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(t.taxa); err != nil {
+		return fmt.Errorf("failed to encode taxonomy: %w", err)
+	}
+	return nil
+}
+
+func (t *Taxonomy) Len() int {
+	return len(t.taxa)
+}
+
+// Taxa returns all taxa in taxonomic order.
+func (t *Taxonomy) Taxa() []EbirdTaxon {
+	taxa := make([]EbirdTaxon, len(t.taxa))
+	copy(taxa, t.taxa)
+	return taxa
+}
+
+func (t *Taxonomy) ByCode(speciesCode string) (EbirdTaxon, bool) {
+	return t.lookup(t.byCode, speciesCode)
+}
+
+// BySciName looks up a taxon by scientific name, ignoring case and extra
+// whitespace.
+func (t *Taxonomy) BySciName(name string) (EbirdTaxon, bool) {
+	return t.lookup(t.bySciName, normalizeName(name))
+}
+
+// ByComName looks up a taxon by common name, ignoring case and extra
+// whitespace.
+func (t *Taxonomy) ByComName(name string) (EbirdTaxon, bool) {
+	return t.lookup(t.byComName, normalizeName(name))
+}
+
+// ByBandingCode looks up a taxon by its four-letter banding code, ignoring
+// case.
+func (t *Taxonomy) ByBandingCode(code string) (EbirdTaxon, bool) {
+	return t.lookup(t.byBanding, normalizeName(code))
+}
+
+func (t *Taxonomy) lookup(index map[string]int, key string) (EbirdTaxon, bool) {
+	i, ok := index[key]
+	if !ok {
+		return EbirdTaxon{}, false
+	}
+	return t.taxa[i], true
+}
+
+// Families returns the family codes in taxonomic order.
+func (t *Taxonomy) Families() []string {
+	return append([]string(nil), t.familyCodes...)
+}
+
+// Family returns the taxa of a family in taxonomic order.
+func (t *Taxonomy) Family(familyCode string) []EbirdTaxon {
+	return t.collect(t.families[familyCode])
+}
+
+// Orders returns the order names in taxonomic order.
+func (t *Taxonomy) Orders() []string {
+	return append([]string(nil), t.orderNames...)
+}
+
+// Order returns the taxa of an order in taxonomic order.
+func (t *Taxonomy) Order(order string) []EbirdTaxon {
+	return t.collect(t.orders[order])
+}
+
+func (t *Taxonomy) collect(indexes []int) []EbirdTaxon {
+	if len(indexes) == 0 {
+		return nil
+	}
+	taxa := make([]EbirdTaxon, 0, len(indexes))
+	for _, i := range indexes {
+		taxa = append(taxa, t.taxa[i])
+	}
+	return taxa
+}
+
+// SortCodes sorts species codes in taxonomic order. Codes that are not in
+// the taxonomy are moved to the end in their original order.
+func (t *Taxonomy) SortCodes(codes []string) {
+	sort.SliceStable(codes, func(i, j int) bool {
+		a, aok := t.byCode[codes[i]]
+		b, bok := t.byCode[codes[j]]
+		if aok && bok {
+			return a < b
+		}
+		return aok && !bok
+	})
+}
+
+// SortTaxa sorts taxa by TaxonOrder.
+func SortTaxa(taxa []EbirdTaxon) {
+	sort.SliceStable(taxa, func(i, j int) bool {
+		return taxa[i].TaxonOrder < taxa[j].TaxonOrder
+	})
+}
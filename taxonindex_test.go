@@ -0,0 +1,113 @@
+package ebird
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTaxa = []EbirdTaxon{
+	{SpeciesCode: "bkcchi", ComName: "Black-capped Chickadee", SciName: "Poecile atricapillus", Category: "species", TaxonOrder: 31234, BandingCodes: []string{"BCCH"}, Order: "Passeriformes", FamilyCode: "parida1"},
+	{SpeciesCode: "ostric2", ComName: "Common Ostrich", SciName: "Struthio camelus", Category: "species", TaxonOrder: 1, Order: "Struthioniformes", FamilyCode: "struth1"},
+	{SpeciesCode: "tuftit", ComName: "Tufted Titmouse", SciName: "Baeolophus bicolor", Category: "species", TaxonOrder: 31270, BandingCodes: []string{"TUTI"}, Order: "Passeriformes", FamilyCode: "parida1"},
+	{SpeciesCode: "amecro", ComName: "American Crow", SciName: "Corvus brachyrhynchos", Category: "species", TaxonOrder: 20000, BandingCodes: []string{"AMCR"}, Order: "Passeriformes", FamilyCode: "corvid1"},
+	{SpeciesCode: "bkcchi", ComName: "Duplicate", TaxonOrder: 99999},
+}
+
+func TestTaxonomyLookup(t *testing.T) {
+	tax := NewTaxonomy(testTaxa)
+	assert.Equal(t, 4, tax.Len())
+
+	taxon, ok := tax.ByCode("bkcchi")
+	require.True(t, ok)
+	assert.Equal(t, "Black-capped Chickadee", taxon.ComName)
+
+	taxon, ok = tax.ByComName("  black-capped   CHICKADEE ")
+	require.True(t, ok)
+	assert.Equal(t, "bkcchi", taxon.SpeciesCode)
+
+	taxon, ok = tax.BySciName("corvus brachyrhynchos")
+	require.True(t, ok)
+	assert.Equal(t, "amecro", taxon.SpeciesCode)
+
+	taxon, ok = tax.ByBandingCode("tuti")
+	require.True(t, ok)
+	assert.Equal(t, "tuftit", taxon.SpeciesCode)
+
+	_, ok = tax.ByCode("nope")
+	assert.False(t, ok)
+	_, ok = tax.ByComName("Duplicate")
+	assert.False(t, ok)
+}
+
+func TestTaxonomyGroupingAndSorting(t *testing.T) {
+	tax := NewTaxonomy(testTaxa)
+
+	var codes []string
+	for _, taxon := range tax.Taxa() {
+		codes = append(codes, taxon.SpeciesCode)
+	}
+	assert.Equal(t, []string{"ostric2", "amecro", "bkcchi", "tuftit"}, codes)
+
+	assert.Equal(t, []string{"struth1", "corvid1", "parida1"}, tax.Families())
+	assert.Len(t, tax.Family("parida1"), 2)
+	assert.Nil(t, tax.Family("missing"))
+	assert.Equal(t, []string{"Struthioniformes", "Passeriformes"}, tax.Orders())
+	assert.Len(t, tax.Order("Passeriformes"), 3)
+
+	codes = []string{"tuftit", "unknown", "ostric2", "bkcchi"}
+	tax.SortCodes(codes)
+	assert.Equal(t, []string{"ostric2", "bkcchi", "tuftit", "unknown"}, codes)
+}
+
+func TestTaxonomyLoad(t *testing.T) {
+	tax, err := ReadTaxonomyCSV(strings.NewReader(taxonomyCSV))
+	require.NoError(t, err)
+	taxon, ok := tax.ByBandingCode("BCCH")
+	require.True(t, ok)
+	assert.Equal(t, "parida1", taxon.FamilyCode)
+
+	var buf bytes.Buffer
+	require.NoError(t, tax.WriteJSON(&buf))
+	path := filepath.Join(t.TempDir(), "taxonomy.json")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+
+	fromFile, err := LoadTaxonomyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, tax.Taxa(), fromFile.Taxa())
+
+	csvPath := filepath.Join(t.TempDir(), "taxonomy.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte(taxonomyCSV), 0o644))
+	fromFile, err = LoadTaxonomyFile(csvPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fromFile.Len())
+
+	_, err = LoadTaxonomyFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestClientLoadTaxonomy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ref/taxonomy/ebird", r.URL.Path)
+		assert.Equal(t, "json", r.URL.Query().Get("fmt"))
+		w.Write([]byte(`[{"speciesCode":"amecro","comName":"American Crow","taxonOrder":20000}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	tax, err := client.LoadTaxonomy(context.Background())
+	require.NoError(t, err)
+	taxon, ok := tax.ByComName("american crow")
+	require.True(t, ok)
+	assert.Equal(t, "amecro", taxon.SpeciesCode)
+}
@@ -3,6 +3,7 @@ package ebird
 import (
 	"context"
 	"fmt"
+	"io"
 )
 
 type TaxonomicGroup struct {
@@ -39,13 +40,13 @@ type EbirdTaxon struct {
 
 func (c *Client) TaxonomicGroups(ctx context.Context, speciesGrouping string, opts ...RequestOption) ([]TaxonomicGroup, error) {
 	if speciesGrouping == "" {
-		return nil, fmt.Errorf("speciesGrouping cannot be empty")
+		return nil, invalidArgument("speciesGrouping cannot be empty")
 	}
 	endpoint := fmt.Sprintf(APIEndpoints.TaxonomicGroups, speciesGrouping)
 	params := processOptions(opts...)
 
 	var groups []TaxonomicGroup
-	err := c.get(ctx, endpoint, params.URLParams, &groups)
+	err := c.get(ctx, endpoint, params, &groups)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get taxonomic groups: %w", err)
 	}
@@ -57,7 +58,7 @@ func (c *Client) TaxonomyVersions(ctx context.Context, opts ...RequestOption) ([
 	params := processOptions(opts...)
 
 	var versions []TaxonomyVersion
-	err := c.get(ctx, APIEndpoints.TaxonomyVersions, params.URLParams, &versions)
+	err := c.get(ctx, APIEndpoints.TaxonomyVersions, params, &versions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get taxonomy versions: %w", err)
 	}
@@ -69,7 +70,7 @@ func (c *Client) TaxaLocaleCodes(ctx context.Context, opts ...RequestOption) ([]
 	params := processOptions(opts...)
 
 	var codes []TaxaLocaleCode
-	err := c.get(ctx, APIEndpoints.TaxaLocaleCodes, params.URLParams, &codes)
+	err := c.get(ctx, APIEndpoints.TaxaLocaleCodes, params, &codes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get taxa locale codes: %w", err)
 	}
@@ -79,14 +80,14 @@ func (c *Client) TaxaLocaleCodes(ctx context.Context, opts ...RequestOption) ([]
 
 func (c *Client) TaxonomicForms(ctx context.Context, speciesCode string, opts ...RequestOption) ([]string, error) {
 	if speciesCode == "" {
-		return nil, fmt.Errorf("speciesCode cannot be empty")
+		return nil, invalidArgument("speciesCode cannot be empty")
 	}
 
 	endpoint := fmt.Sprintf(APIEndpoints.TaxonomicForms, speciesCode)
 	params := processOptions(opts...)
 
 	var forms []string
-	err := c.get(ctx, endpoint, params.URLParams, &forms)
+	err := c.get(ctx, endpoint, params, &forms)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get taxonomic forms: %w", err)
 	}
@@ -96,13 +97,27 @@ func (c *Client) TaxonomicForms(ctx context.Context, speciesCode string, opts ..
 
 func (c *Client) EbirdTaxonomy(ctx context.Context, opts ...RequestOption) ([]EbirdTaxon, error) {
 	params := processOptions(opts...)
-	params.URLParams.Set("fmt", "json")
+	defaultFormat(params, "json")
 
 	var taxonomy []EbirdTaxon
-	err := c.get(ctx, APIEndpoints.EbirdTaxonomy, params.URLParams, &taxonomy)
+	err := c.get(ctx, APIEndpoints.EbirdTaxonomy, params, &taxonomy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get eBird taxonomy: %w", err)
 	}
 
 	return taxonomy, nil
 }
+
+// EbirdTaxonomyCSV streams the taxonomy as CSV without decoding it. The
+// caller must close the returned reader.
+func (c *Client) EbirdTaxonomyCSV(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error) {
+	params := processOptions(opts...)
+	params.URLParams.Set("fmt", "csv")
+
+	body, err := c.getRaw(ctx, APIEndpoints.EbirdTaxonomy, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eBird taxonomy: %w", err)
+	}
+
+	return body, nil
+}
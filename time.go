@@ -0,0 +1,191 @@
+package ebird
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var dateTimeLayouts = []struct {
+	layout  string
+	hasTime bool
+}{
+	{"2006-01-02 15:04", true},
+	{"2006-01-02 15:04:05", true},
+	{"2006-01-02", false},
+	{"2 Jan 2006 15:04", true},
+	{"2 Jan 2006", false},
+}
+
+// ParseDateTime parses an eBird date such as "2023-10-05 21:42",
+// "2023-10-05" or "6 Oct 2023" in loc. The boolean reports whether the value
+// included a time of day; date-only values are returned at midnight.
+func ParseDateTime(value string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false, fmt.Errorf("empty date")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, l := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(l.layout, value, loc); err == nil {
+			return t, l.hasTime, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("unrecognized date %q", value)
+}
+
+// WithParsedTimes makes the client fill the *Parsed time fields of
+// observations, checklists and hotspots as responses are decoded. Values
+// that cannot be parsed are left as the zero time.
+func WithParsedTimes() ClientOption {
+	return func(client *Client) {
+		client.parseTimes = true
+	}
+}
+
+// ObsTime parses ObsDt in the time zone of the observation's region, taken
+// from its subnational or country code. Observations without a region code
+// fall back to a fixed offset derived from the longitude, which ignores
+// daylight saving time; use ObsTimeIn when the region is known.
+func (o Observation) ObsTime() (time.Time, bool, error) {
+	return o.ObsTimeIn("")
+}
+
+// ObsTimeIn is like ObsTime but uses regionCode, such as the region the
+// observations were requested for, when the observation has no region code
+// of its own.
+func (o Observation) ObsTimeIn(regionCode string) (time.Time, bool, error) {
+	if code := observationRegion(o); code != "" {
+		regionCode = code
+	}
+	return ParseDateTime(o.ObsDt, TimeZone(regionCode, o.Lng))
+}
+
+// ObsDateTime parses the observation date and time of the checklist in the
+// time zone of its location.
+func (f RecentChecklistFeed) ObsDateTime() (time.Time, bool, error) {
+	loc := TimeZone(locationRegion(f.Loc.Subnational1Code, f.Loc.CountryCode), locationLng(f.Loc.Lng, f.Loc.Longitude))
+	return parseFeedDateTime(f.IsoObsDate, f.ObsDt, f.ObsTime, loc)
+}
+
+// ObsDateTime parses the observation date and time of the checklist in the
+// time zone of its location.
+func (f ChecklistFeedOnDate) ObsDateTime() (time.Time, bool, error) {
+	loc := TimeZone(locationRegion(f.Loc.Subnational1Code, f.Loc.CountryCode), locationLng(f.Loc.Lng, f.Loc.Longitude))
+	return parseFeedDateTime(f.IsoObsDate, f.ObsDt, f.ObsTime, loc)
+}
+
+// ObsTime parses ObsDt in the time zone of the checklist's region. Checklists
+// carry no coordinates, so a region without a known zone returns
+// ErrUnknownTimeZone.
+func (v ViewChecklist) ObsTime() (time.Time, bool, error) {
+	return v.parseTime(v.ObsDt)
+}
+
+func (v ViewChecklist) CreationTime() (time.Time, bool, error) {
+	return v.parseTime(v.CreationDt)
+}
+
+func (v ViewChecklist) LastEditedTime() (time.Time, bool, error) {
+	return v.parseTime(v.LastEditedDt)
+}
+
+func (v ViewChecklist) parseTime(value string) (time.Time, bool, error) {
+	loc, err := v.timeZone()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return ParseDateTime(value, loc)
+}
+
+func (v ViewChecklist) timeZone() (*time.Location, error) {
+	if loc := regionTimeZone(v.Subnational1Code); loc != nil {
+		return loc, nil
+	}
+	return nil, fmt.Errorf("%w for region %q", ErrUnknownTimeZone, v.Subnational1Code)
+}
+
+func (h NearbyHotspot) LatestObsTime() (time.Time, bool, error) {
+	return ParseDateTime(h.LatestObsDt, TimeZone(locationRegion(h.Subnational1Code, h.CountryCode), h.Lng))
+}
+
+func (h HotspotInRegion) LatestObsTime() (time.Time, bool, error) {
+	return ParseDateTime(h.LatestObsDt, TimeZone(locationRegion(h.Subnational1Code, h.CountryCode), h.Lng))
+}
+
+func parseFeedDateTime(iso, date, clock string, loc *time.Location) (time.Time, bool, error) {
+	if iso != "" {
+		return ParseDateTime(iso, loc)
+	}
+	if clock != "" {
+		return ParseDateTime(date+" "+clock, loc)
+	}
+	return ParseDateTime(date, loc)
+}
+
+func observationRegion(o Observation) string {
+	if o.Subnational2Code != "" {
+		return o.Subnational2Code
+	}
+	return locationRegion(o.Subnational1Code, o.CountryCode)
+}
+
+func locationRegion(subnational1Code, countryCode string) string {
+	if subnational1Code != "" {
+		return subnational1Code
+	}
+	return countryCode
+}
+
+func locationLng(lng, longitude float64) float64 {
+	if lng != 0 {
+		return lng
+	}
+	return longitude
+}
+
+func parsedTime(t time.Time, _ bool, err error) time.Time {
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// fillParsedTimes sets the parsed time fields of result. regionCode is the
+// region the request was made for, or "".
+func fillParsedTimes(result interface{}, regionCode string) {
+	switch v := result.(type) {
+	case *[]Observation:
+		for i := range *v {
+			(*v)[i].ObsDtParsed = parsedTime((*v)[i].ObsTimeIn(regionCode))
+		}
+	case *[]RecentChecklistFeed:
+		for i := range *v {
+			(*v)[i].ObsDtParsed = parsedTime((*v)[i].ObsDateTime())
+		}
+	case *[]ChecklistFeedOnDate:
+		for i := range *v {
+			(*v)[i].ObsDtParsed = parsedTime((*v)[i].ObsDateTime())
+		}
+	case *ViewChecklist:
+		v.ObsDtParsed = parsedTime(v.ObsTime())
+		v.CreationDtParsed = parsedTime(v.CreationTime())
+		v.LastEditedDtParsed = parsedTime(v.LastEditedTime())
+		if loc, err := v.timeZone(); err == nil {
+			for i := range v.Obs {
+				v.Obs[i].ObsDtParsed = parsedTime(ParseDateTime(v.Obs[i].ObsDt, loc))
+			}
+		}
+	case *[]NearbyHotspot:
+		for i := range *v {
+			(*v)[i].LatestObsDtParsed = parsedTime((*v)[i].LatestObsTime())
+		}
+	case *[]HotspotInRegion:
+		for i := range *v {
+			(*v)[i].LatestObsDtParsed = parsedTime((*v)[i].LatestObsTime())
+		}
+	}
+}
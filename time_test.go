@@ -0,0 +1,167 @@
+package ebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDateTime(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*3600)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		hasTime bool
+		wantErr bool
+	}{
+		{value: "2023-10-05 21:42", want: time.Date(2023, 10, 5, 21, 42, 0, 0, loc), hasTime: true},
+		{value: "2023-10-05 21:42:30", want: time.Date(2023, 10, 5, 21, 42, 30, 0, loc), hasTime: true},
+		{value: "2023-10-05", want: time.Date(2023, 10, 5, 0, 0, 0, 0, loc)},
+		{value: "6 Oct 2023 03:35", want: time.Date(2023, 10, 6, 3, 35, 0, 0, loc), hasTime: true},
+		{value: "6 Oct 2023", want: time.Date(2023, 10, 6, 0, 0, 0, 0, loc)},
+		{value: "", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, hasTime, err := ParseDateTime(tt.value, loc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v", got)
+			assert.Equal(t, tt.hasTime, hasTime)
+		})
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	assert.Equal(t, ny.String(), TimeZone("US-NY", -76).String())
+	assert.Equal(t, ny.String(), TimeZone("US-NY-109", 0).String())
+	assert.Equal(t, "Asia/Taipei", TimeZone("TW-TPE", 0).String())
+	assert.Equal(t, "UTC-5", TimeZone("", -76.5).String())
+	assert.Equal(t, "UTC+9", TimeZone("RU-KHA", 135).String())
+	assert.Equal(t, time.UTC, TimeZone("", 3))
+}
+
+func TestTimeHelpers(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	obs := Observation{ObsDt: "2023-10-05 21:42", Lng: -76.5}
+	got, hasTime, err := obs.ObsTime()
+	require.NoError(t, err)
+	assert.True(t, hasTime)
+	assert.Equal(t, "2023-10-06T02:42:00Z", got.UTC().Format(time.RFC3339))
+
+	feed := RecentChecklistFeed{ObsDt: "6 Oct 2023", ObsTime: "03:35", Loc: Location{Subnational1Code: "US-CT"}}
+	got, hasTime, err = feed.ObsDateTime()
+	require.NoError(t, err)
+	assert.True(t, hasTime)
+	assert.Equal(t, "2023-10-06T07:35:00Z", got.UTC().Format(time.RFC3339))
+
+	feed = RecentChecklistFeed{ObsDt: "6 Oct 2023", Loc: Location{CountryCode: "TW"}}
+	got, hasTime, err = feed.ObsDateTime()
+	require.NoError(t, err)
+	assert.False(t, hasTime)
+	assert.Equal(t, "2023-10-05T16:00:00Z", got.UTC().Format(time.RFC3339))
+
+	checklist := ViewChecklist{CreationDt: "2023-10-06 03:41", LastEditedDt: "bad", Subnational1Code: "US-CA"}
+	got, _, err = checklist.CreationTime()
+	require.NoError(t, err)
+	assert.Equal(t, "America/Los_Angeles", got.Location().String())
+	_, _, err = checklist.LastEditedTime()
+	assert.Error(t, err)
+
+	checklist = ViewChecklist{ObsDt: "2023-10-06 03:41", Subnational1Code: "MX-ROO"}
+	_, _, err = checklist.ObsTime()
+	assert.ErrorIs(t, err, ErrUnknownTimeZone)
+
+	checklist = ViewChecklist{ObsDt: "2023-10-06 03:41", Subnational1Code: "ES-CT"}
+	got, _, err = checklist.ObsTime()
+	require.NoError(t, err)
+	assert.Equal(t, "2023-10-06T01:41:00Z", got.UTC().Format(time.RFC3339))
+
+	hotspot := NearbyHotspot{LatestObsDt: "2023-09-20 09:00", Subnational1Code: "CA-AB"}
+	got, _, err = hotspot.LatestObsTime()
+	require.NoError(t, err)
+	assert.Equal(t, "America/Edmonton", got.Location().String())
+}
+
+func TestObsTimeRegion(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	tests := []struct {
+		name   string
+		obs    Observation
+		region string
+		want   string
+	}{
+		{name: "daylight saving time", obs: Observation{ObsDt: "2024-07-04 09:00", Lng: -73.9}, region: "US-NY-061", want: "2024-07-04T13:00:00Z"},
+		{name: "standard time", obs: Observation{ObsDt: "2024-01-04 09:00", Lng: -73.9}, region: "US-NY", want: "2024-01-04T14:00:00Z"},
+		{name: "half-hour zone", obs: Observation{ObsDt: "2024-07-04 09:00", Lng: 77.6, Subnational1Code: "IN-KA"}, want: "2024-07-04T03:30:00Z"},
+		{name: "observation region wins", obs: Observation{ObsDt: "2024-07-04 09:00", Lng: -118.2, Subnational2Code: "US-CA-037"}, region: "US", want: "2024-07-04T16:00:00Z"},
+		{name: "longitude fallback", obs: Observation{ObsDt: "2024-07-04 09:00", Lng: -73.9}, want: "2024-07-04T14:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasTime, err := tt.obs.ObsTimeIn(tt.region)
+			require.NoError(t, err)
+			assert.True(t, hasTime)
+			assert.Equal(t, tt.want, got.UTC().Format(time.RFC3339))
+		})
+	}
+}
+
+func TestWithParsedTimes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/obs/US-NY/recent":
+			w.Write([]byte(`[{"speciesCode":"amecro","obsDt":"2023-10-05 21:42","lng":-76.5},{"speciesCode":"blujay","obsDt":"garbage"}]`))
+		case "/product/checklist/view/S1":
+			w.Write([]byte(`{"subId":"S1","obsDt":"2023-10-05 08:00","creationDt":"2023-10-05 10:00","subnational1Code":"US-NY","obs":[{"speciesCode":"amecro","obsDt":"2023-10-05 08:00"}]}`))
+		case "/ref/hotspot/US-NY":
+			w.Write([]byte(`[{"locId":"L1","subnational1Code":"US-NY","latestObsDt":"2023-09-20 09:00"}]`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithParsedTimes())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	observations, err := client.RecentObservationsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+	want, _, _ := observations[0].ObsTimeIn("US-NY")
+	assert.True(t, want.Equal(observations[0].ObsDtParsed))
+	assert.Equal(t, "2023-10-06T01:42:00Z", observations[0].ObsDtParsed.UTC().Format(time.RFC3339))
+	assert.False(t, observations[0].ObsDtParsed.IsZero())
+	assert.True(t, observations[1].ObsDtParsed.IsZero())
+
+	checklist, err := client.ViewChecklist(ctx, "S1")
+	require.NoError(t, err)
+	assert.False(t, checklist.ObsDtParsed.IsZero())
+	assert.False(t, checklist.CreationDtParsed.IsZero())
+	assert.True(t, checklist.LastEditedDtParsed.IsZero())
+	assert.True(t, checklist.ObsDtParsed.Equal(checklist.Obs[0].ObsDtParsed))
+
+	hotspots, err := client.HotspotsInRegion(ctx, "US-NY")
+	require.NoError(t, err)
+	assert.False(t, hotspots[0].LatestObsDtParsed.IsZero())
+}
@@ -0,0 +1,132 @@
+package ebird
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// regionTimeZones maps region codes to IANA time zones. Subnational1 codes
+// are listed for countries that span several zones; states that are split
+// use the zone covering most of their area.
+var regionTimeZones = map[string]string{
+	"US-AL": "America/Chicago", "US-AK": "America/Anchorage", "US-AZ": "America/Phoenix",
+	"US-AR": "America/Chicago", "US-CA": "America/Los_Angeles", "US-CO": "America/Denver",
+	"US-CT": "America/New_York", "US-DC": "America/New_York", "US-DE": "America/New_York",
+	"US-FL": "America/New_York", "US-GA": "America/New_York", "US-HI": "Pacific/Honolulu",
+	"US-ID": "America/Boise", "US-IL": "America/Chicago", "US-IN": "America/Indiana/Indianapolis",
+	"US-IA": "America/Chicago", "US-KS": "America/Chicago", "US-KY": "America/New_York",
+	"US-LA": "America/Chicago", "US-ME": "America/New_York", "US-MD": "America/New_York",
+	"US-MA": "America/New_York", "US-MI": "America/Detroit", "US-MN": "America/Chicago",
+	"US-MS": "America/Chicago", "US-MO": "America/Chicago", "US-MT": "America/Denver",
+	"US-NE": "America/Chicago", "US-NV": "America/Los_Angeles", "US-NH": "America/New_York",
+	"US-NJ": "America/New_York", "US-NM": "America/Denver", "US-NY": "America/New_York",
+	"US-NC": "America/New_York", "US-ND": "America/Chicago", "US-OH": "America/New_York",
+	"US-OK": "America/Chicago", "US-OR": "America/Los_Angeles", "US-PA": "America/New_York",
+	"US-RI": "America/New_York", "US-SC": "America/New_York", "US-SD": "America/Chicago",
+	"US-TN": "America/Chicago", "US-TX": "America/Chicago", "US-UT": "America/Denver",
+	"US-VT": "America/New_York", "US-VA": "America/New_York", "US-WA": "America/Los_Angeles",
+	"US-WV": "America/New_York", "US-WI": "America/Chicago", "US-WY": "America/Denver",
+
+	"CA-AB": "America/Edmonton", "CA-BC": "America/Vancouver", "CA-MB": "America/Winnipeg",
+	"CA-NB": "America/Moncton", "CA-NL": "America/St_Johns", "CA-NS": "America/Halifax",
+	"CA-NT": "America/Yellowknife", "CA-NU": "America/Iqaluit", "CA-ON": "America/Toronto",
+	"CA-PE": "America/Halifax", "CA-QC": "America/Montreal", "CA-SK": "America/Regina",
+	"CA-YT": "America/Whitehorse",
+
+	"AU-ACT": "Australia/Sydney", "AU-NSW": "Australia/Sydney", "AU-NT": "Australia/Darwin",
+	"AU-QLD": "Australia/Brisbane", "AU-SA": "Australia/Adelaide", "AU-TAS": "Australia/Hobart",
+	"AU-VIC": "Australia/Melbourne", "AU-WA": "Australia/Perth",
+
+	"AR": "America/Argentina/Buenos_Aires", "AT": "Europe/Vienna", "BD": "Asia/Dhaka",
+	"BE": "Europe/Brussels", "BG": "Europe/Sofia", "BO": "America/La_Paz", "BT": "Asia/Thimphu",
+	"BW": "Africa/Gaborone", "BZ": "America/Belize", "CH": "Europe/Zurich", "CN": "Asia/Shanghai",
+	"CL": "America/Santiago", "CO": "America/Bogota", "CR": "America/Costa_Rica", "CU": "America/Havana", "CY": "Asia/Nicosia",
+	"CZ": "Europe/Prague", "DE": "Europe/Berlin", "DK": "Europe/Copenhagen", "DO": "America/Santo_Domingo",
+	"DZ": "Africa/Algiers", "EC": "America/Guayaquil", "EE": "Europe/Tallinn", "EG": "Africa/Cairo",
+	"ES": "Europe/Madrid", "ET": "Africa/Addis_Ababa",
+	"FI": "Europe/Helsinki", "FR": "Europe/Paris", "GB": "Europe/London", "GH": "Africa/Accra",
+	"GR": "Europe/Athens", "GT": "America/Guatemala", "HK": "Asia/Hong_Kong", "HN": "America/Tegucigalpa",
+	"HR": "Europe/Zagreb", "HU": "Europe/Budapest", "IE": "Europe/Dublin", "IL": "Asia/Jerusalem",
+	"IN": "Asia/Kolkata", "IS": "Atlantic/Reykjavik", "IT": "Europe/Rome", "JM": "America/Jamaica",
+	"JO": "Asia/Amman", "JP": "Asia/Tokyo", "KE": "Africa/Nairobi", "KH": "Asia/Phnom_Penh",
+	"KR": "Asia/Seoul", "LA": "Asia/Vientiane", "LK": "Asia/Colombo", "LT": "Europe/Vilnius",
+	"LU": "Europe/Luxembourg", "LV": "Europe/Riga", "MA": "Africa/Casablanca", "MG": "Indian/Antananarivo",
+	"MM": "Asia/Yangon", "MT": "Europe/Malta", "MW": "Africa/Blantyre", "MY": "Asia/Kuala_Lumpur",
+	"MZ": "Africa/Maputo", "NA": "Africa/Windhoek", "NG": "Africa/Lagos", "NI": "America/Managua",
+	"NL": "Europe/Amsterdam", "NO": "Europe/Oslo", "NP": "Asia/Kathmandu", "NZ": "Pacific/Auckland",
+	"OM": "Asia/Muscat",
+	"PA": "America/Panama", "PE": "America/Lima", "PH": "Asia/Manila", "PK": "Asia/Karachi",
+	"PL": "Europe/Warsaw", "PR": "America/Puerto_Rico", "PT": "Europe/Lisbon", "PY": "America/Asuncion", "QA": "Asia/Qatar",
+	"RO": "Europe/Bucharest", "RS": "Europe/Belgrade", "RW": "Africa/Kigali", "SA": "Asia/Riyadh",
+	"SE": "Europe/Stockholm", "SG": "Asia/Singapore", "SI": "Europe/Ljubljana", "SK": "Europe/Bratislava",
+	"SN": "Africa/Dakar", "SV": "America/El_Salvador", "TH": "Asia/Bangkok", "TN": "Africa/Tunis",
+	"TR": "Europe/Istanbul", "TT": "America/Port_of_Spain", "TW": "Asia/Taipei", "TZ": "Africa/Dar_es_Salaam",
+	"UA": "Europe/Kyiv", "UG": "Africa/Kampala", "UY": "America/Montevideo", "VE": "America/Caracas",
+	"VN": "Asia/Ho_Chi_Minh", "ZA": "Africa/Johannesburg", "ZM": "Africa/Lusaka", "ZW": "Africa/Harare",
+}
+
+// ErrUnknownTimeZone is returned when parsing the dates of a record that
+// has neither a known region code nor coordinates to derive a zone from.
+var ErrUnknownTimeZone = errors.New("ebird: unknown time zone")
+
+var (
+	timeZoneMu    sync.Mutex
+	timeZoneCache = make(map[string]*time.Location)
+)
+
+// TimeZone returns the local time zone for a region code, trying the code
+// itself and then its country. If the region is unknown, or the zone
+// database is unavailable, it falls back to a fixed offset derived from the
+// longitude. Pass an empty region code to use coordinates only.
+func TimeZone(regionCode string, lng float64) *time.Location {
+	if loc := regionTimeZone(regionCode); loc != nil {
+		return loc
+	}
+	return longitudeTimeZone(lng)
+}
+
+// regionTimeZone is TimeZone without the longitude fallback. It returns nil
+// if the region is unknown.
+func regionTimeZone(regionCode string) *time.Location {
+	for code := regionCode; code != ""; code = parentCode(code) {
+		if name, ok := regionTimeZones[code]; ok {
+			return loadTimeZone(name)
+		}
+	}
+	return nil
+}
+
+func parentCode(code string) string {
+	i := strings.LastIndexByte(code, '-')
+	if i < 0 {
+		return ""
+	}
+	return code[:i]
+}
+
+func loadTimeZone(name string) *time.Location {
+	timeZoneMu.Lock()
+	defer timeZoneMu.Unlock()
+
+	if loc, ok := timeZoneCache[name]; ok {
+		return loc
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = nil
+	}
+	timeZoneCache[name] = loc
+	return loc
+}
+
+func longitudeTimeZone(lng float64) *time.Location {
+	hours := int(math.Round(lng / 15))
+	if hours == 0 {
+		return time.UTC
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%+d", hours), hours*3600)
+}
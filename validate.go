@@ -0,0 +1,132 @@
+package ebird
+
+import (
+	"fmt"
+	"sort"
+)
+
+// OptionError reports a RequestOption that was rejected in strict mode,
+// either because its value is out of range or because the endpoint does not
+// support it.
+type OptionError struct {
+	Option   string
+	Value    interface{}
+	Endpoint string
+	Reason   string
+}
+
+func (e *OptionError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("option %s %s", e.Option, e.Reason)
+	}
+	return fmt.Sprintf("invalid option %s(%v): %s", e.Option, e.Value, e.Reason)
+}
+
+func (e *OptionError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// WithStrictOptions makes every request fail with an *OptionError when a
+// RequestOption is invalid or unsupported by the endpoint. By default such
+// options are silently dropped.
+func WithStrictOptions() ClientOption {
+	return func(client *Client) {
+		client.strictOptions = true
+	}
+}
+
+var validCategories = []string{"species", "slash", "issf", "spuh", "hybrid", "domestic", "form", "intergrade"}
+
+// optionNames maps URL parameters back to the RequestOption that sets them.
+var optionNames = map[string]string{
+	"back":               "Back",
+	"cat":                "Cat",
+	"delim":              "Delim",
+	"dist":               "Dist",
+	"fmt":                "Fmt",
+	"groupNameLocale":    "GroupNameLocale",
+	"hotspot":            "Hotspot",
+	"includeProvisional": "IncludeProvisional",
+	"lat":                "Lat",
+	"lng":                "Lng",
+	"locale":             "Locale",
+	"maxResults":         "MaxResults",
+	"r":                  "R",
+	"rankedBy":           "RankedBy",
+	"regionNameFormat":   "RegionNameFormat",
+	"sortKey":            "SortKey",
+	"species":            "Species",
+	"sppLocale":          "SppLocale",
+	"version":            "Version",
+}
+
+// endpointParams lists the URL parameters each endpoint accepts, keyed by
+// APIEndpoints field name.
+var endpointParams = map[string][]string{
+	"RecentObservationsInRegion":          {"back", "cat", "hotspot", "includeProvisional", "maxResults", "r", "sppLocale"},
+	"RecentNotableObservationsInRegion":   {"back", "hotspot", "maxResults", "r", "sppLocale"},
+	"RecentObservationsOfSpeciesInRegion": {"back", "hotspot", "includeProvisional", "maxResults", "r", "sppLocale"},
+	"RecentNearbyObservations":            {"lat", "lng", "dist", "back", "cat", "hotspot", "includeProvisional", "maxResults", "sppLocale"},
+	"RecentNearbyObservationsOfSpecies":   {"lat", "lng", "dist", "back", "hotspot", "includeProvisional", "maxResults", "sppLocale"},
+	"NearestObservationsOfSpecies":        {"lat", "lng", "dist", "back", "hotspot", "includeProvisional", "maxResults", "sppLocale"},
+	"RecentNearbyNotableObservations":     {"lat", "lng", "dist", "back", "hotspot", "maxResults", "sppLocale"},
+	"HistoricObservationsOnDate":          {"cat", "hotspot", "includeProvisional", "maxResults", "r", "sppLocale"},
+	"RecentChecklistsFeed":                {"maxResults"},
+	"ChecklistFeedOnDate":                 {"sortKey", "maxResults"},
+	"RegionalStatisticsOnDate":            {},
+	"SpeciesListForRegion":                {},
+	"Top100":                              {"rankedBy", "maxResults"},
+	"ViewChecklist":                       {},
+	"AdjacentRegions":                     {"fmt"},
+	"HotspotInfo":                         {},
+	"HotspotsInRegion":                    {"back", "fmt"},
+	"NearbyHotspots":                      {"lat", "lng", "back", "dist", "fmt"},
+	"EbirdTaxonomy":                       {"cat", "fmt", "locale", "species", "version"},
+	"TaxaLocaleCodes":                     {},
+	"TaxonomicForms":                      {},
+	"TaxonomicGroups":                     {"groupNameLocale"},
+	"TaxonomyVersions":                    {},
+	"RegionInfo":                          {"regionNameFormat", "delim"},
+	"SubRegionInfo":                       {"fmt"},
+}
+
+func (o *RequestOptions) invalid(option string, value interface{}, reason string) {
+	o.errs = append(o.errs, &OptionError{Option: option, Value: value, Reason: reason})
+}
+
+// validate returns the first invalid or unsupported option for endpoint.
+// Endpoints missing from endpointParams accept any parameter.
+func (o RequestOptions) validate(endpoint string) error {
+	if len(o.errs) > 0 {
+		err := *o.errs[0].(*OptionError)
+		err.Endpoint = endpoint
+		return &err
+	}
+
+	allowed, ok := endpointParams[endpoint]
+	if !ok {
+		return nil
+	}
+
+	params := make([]string, 0, len(o.URLParams))
+	for param := range o.URLParams {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		if contains(allowed, param) {
+			continue
+		}
+		option, ok := optionNames[param]
+		if !ok {
+			option = fmt.Sprintf("%q", param)
+		}
+		return &OptionError{
+			Option:   option,
+			Endpoint: endpoint,
+			Reason:   "is not supported by " + endpoint,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package ebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictOptions(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	strict, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithStrictOptions())
+	require.NoError(t, err)
+	lenient, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func(c *Client, opts ...RequestOption) error
+		opts    []RequestOption
+		option  string
+		message string
+	}{
+		{
+			name: "Back out of range",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.RecentObservationsInRegion(ctx, "US-NY", opts...)
+				return err
+			},
+			opts:    []RequestOption{Back(45)},
+			option:  "Back",
+			message: "invalid option Back(45): must be between 1 and 30",
+		},
+		{
+			name: "Dist out of range",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.RecentNearbyObservations(ctx, opts...)
+				return err
+			},
+			opts:    []RequestOption{Lat(42), Lng(-76), Dist(900)},
+			option:  "Dist",
+			message: "invalid option Dist(900): must be between 0 and 500",
+		},
+		{
+			name: "MaxResults zero",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.RecentChecklistsFeed(ctx, "US-NY", opts...)
+				return err
+			},
+			opts:    []RequestOption{MaxResults(0)},
+			option:  "MaxResults",
+			message: "invalid option MaxResults(0): must be between 1 and 100",
+		},
+		{
+			name: "Too many locations",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.RecentObservationsInRegion(ctx, "US-NY", opts...)
+				return err
+			},
+			opts:    []RequestOption{R("L1", "L2", "L3", "L4", "L5", "L6", "L7", "L8", "L9", "L10", "L11")},
+			option:  "R",
+			message: "takes 1 to 10 locations, got 11",
+		},
+		{
+			name: "Unknown sort key",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.ChecklistFeedOnDate(ctx, "US-NY", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), opts...)
+				return err
+			},
+			opts:    []RequestOption{SortKey("foo")},
+			option:  "SortKey",
+			message: `invalid option SortKey(foo): must be "obs_dt" or "creation_dt"`,
+		},
+		{
+			name: "Unknown category",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.EbirdTaxonomy(ctx, opts...)
+				return err
+			},
+			opts:    []RequestOption{Cat("species,bird")},
+			option:  "Cat",
+			message: `unknown category "bird"`,
+		},
+		{
+			name: "Option not supported by endpoint",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.RecentObservationsInRegion(ctx, "US-NY", opts...)
+				return err
+			},
+			opts:    []RequestOption{RankedBy("spp")},
+			option:  "RankedBy",
+			message: "option RankedBy is not supported by RecentObservationsInRegion",
+		},
+		{
+			name: "Raw parameter not supported by endpoint",
+			call: func(c *Client, opts ...RequestOption) error {
+				_, err := c.SpeciesListForRegion(ctx, "US-NY", opts...)
+				return err
+			},
+			opts: []RequestOption{func(o *RequestOptions) {
+				o.URLParams.Set("detail", "full")
+			}},
+			option:  `"detail"`,
+			message: `option "detail" is not supported by SpeciesListForRegion`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+
+			err := tt.call(strict, tt.opts...)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+			assert.Contains(t, err.Error(), tt.message)
+
+			var optErr *OptionError
+			require.True(t, errors.As(err, &optErr))
+			assert.Equal(t, tt.option, optErr.Option)
+			assert.NotEmpty(t, optErr.Endpoint)
+			assert.Equal(t, int32(0), atomic.LoadInt32(&requests), "no request should be sent")
+
+			err = tt.call(lenient, tt.opts...)
+			assert.NoError(t, err)
+
+			err = tt.call(lenient, append(tt.opts, Strict())...)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
+
+func TestStrictOptionsAcceptsValidOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"), WithStrictOptions())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.RecentObservationsInRegion(ctx, "US-NY", Back(7), MaxResults(50), Hotspot(true), Cat("species,issf"))
+	assert.NoError(t, err)
+	_, err = client.Top100(ctx, "US-NY", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), RankedBy("cl"), MaxResults(10))
+	assert.NoError(t, err)
+	_, err = client.NearbyHotspots(ctx, Lat(42.4), Lng(-76.5), Dist(25))
+	assert.NoError(t, err)
+	_, err = client.HotspotsInRegion(ctx, "US-NY", Fmt("json"))
+	assert.NoError(t, err)
+	_, err = client.AdjacentRegions(ctx, "US-NY", WithFormat("json"))
+	assert.NoError(t, err)
+}
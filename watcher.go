@@ -0,0 +1,606 @@
+package ebird
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWatchInterval       = 5 * time.Minute
+	defaultWatchRetention      = 30 * 24 * time.Hour
+	defaultWatchNotifyAttempts = 12
+)
+
+// WatchTarget is a region, or a point and radius, to poll for notable
+// observations. Targets with a RegionCode use
+// RecentNotableObservationsInRegion; the others use
+// RecentNearbyNotableObservations.
+type WatchTarget struct {
+	RegionCode string  `json:"regionCode,omitempty"`
+	Lat        float64 `json:"lat,omitempty"`
+	Lng        float64 `json:"lng,omitempty"`
+	// Dist is the radius in km around a point. Zero uses the API default.
+	Dist int `json:"dist,omitempty"`
+}
+
+func (t WatchTarget) String() string {
+	if t.RegionCode != "" {
+		return t.RegionCode
+	}
+	if t.Dist > 0 {
+		return fmt.Sprintf("%.2f,%.2f (%d km)", t.Lat, t.Lng, t.Dist)
+	}
+	return fmt.Sprintf("%.2f,%.2f", t.Lat, t.Lng)
+}
+
+// Alert is a notable observation that the watcher had not reported before.
+type Alert struct {
+	Observation
+	Target  WatchTarget `json:"target"`
+	FoundAt time.Time   `json:"foundAt"`
+}
+
+// String formats the alert as a single line with a link to the checklist.
+func (a Alert) String() string {
+	name := a.ComName
+	if name == "" {
+		name = a.SpeciesCode
+	}
+	count := ""
+	if a.HowMany > 0 {
+		count = fmt.Sprintf(" x%d", a.HowMany)
+	}
+	return fmt.Sprintf("%s%s at %s on %s (%s) https://ebird.org/checklist/%s", name, count, a.LocName, a.ObsDt, a.Target, a.SubId)
+}
+
+// PendingAlert is an alert that Run has not yet delivered to every notifier.
+type PendingAlert struct {
+	Alert
+	// Notifiers are the indexes, in the order they were added with
+	// WithNotifiers, of the notifiers that have not accepted the alert.
+	Notifiers []int `json:"notifiers"`
+	// Attempts is the number of polls that tried to deliver the alert.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// WatchState is the persistent state of a Watcher: the observations already
+// seen, keyed on SubId and SpeciesCode, when each species was last reported,
+// and the alerts found but not yet delivered by Run. It can be marshaled to
+// JSON and passed back with WithWatchState.
+type WatchState struct {
+	Seen        map[string]time.Time `json:"seen"`
+	LastAlerted map[string]time.Time `json:"lastAlerted,omitempty"`
+	Pending     []PendingAlert       `json:"pending,omitempty"`
+}
+
+// LoadWatchState reads a WatchState saved with Save. A missing file returns
+// an empty state.
+func LoadWatchState(path string) (WatchState, error) {
+	var state WatchState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read watch state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to decode watch state: %w", err)
+	}
+	return state, nil
+}
+
+// Save writes the state to path as JSON, replacing the file atomically.
+func (s WatchState) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode watch state: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".watch-*")
+	if err != nil {
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	return nil
+}
+
+// TargetError is a target that could not be polled.
+type TargetError struct {
+	Target WatchTarget
+	Err    error
+}
+
+// WatchError reports the targets of a poll that failed. Alerts from the
+// other targets are still returned.
+type WatchError struct {
+	Failures []TargetError
+}
+
+func (e *WatchError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", f.Target, f.Err))
+	}
+	return fmt.Sprintf("failed to poll %d targets: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of the first failed target.
+func (e *WatchError) Unwrap() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e.Failures[0].Err
+}
+
+// Watcher polls a set of targets for notable observations on a schedule and
+// hands the ones it has not seen before to its notifiers.
+type Watcher struct {
+	client         ObservationsAPI
+	targets        []WatchTarget
+	interval       time.Duration
+	retention      time.Duration
+	requestOpts    []RequestOption
+	notifiers      []Notifier
+	notifyAttempts int
+	onError        func(error)
+	stateFile      string
+	alertOnFirst   bool
+	suppressed     map[string]bool
+	cooldown       time.Duration
+	quietStart     int
+	quietEnd       int
+	quietLoc       *time.Location
+	now            func() time.Time
+
+	mu          sync.Mutex
+	seen        map[string]time.Time
+	lastAlerted map[string]time.Time
+	pending     []PendingAlert
+	primed      bool
+}
+
+type WatcherOption func(*Watcher)
+
+// WithWatchInterval sets how often Run polls. The default is 5 minutes.
+func WithWatchInterval(d time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if d > 0 {
+			w.interval = d
+		}
+	}
+}
+
+// WithWatchRetention sets how long seen observations are remembered. It
+// should be longer than the Back option of the polls. The default is 30
+// days, the longest Back allows.
+func WithWatchRetention(d time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if d > 0 {
+			w.retention = d
+		}
+	}
+}
+
+// WithWatchRequestOptions sets request options, such as Back or Hotspot,
+// for every poll.
+func WithWatchRequestOptions(opts ...RequestOption) WatcherOption {
+	return func(w *Watcher) {
+		w.requestOpts = append(w.requestOpts, opts...)
+	}
+}
+
+// WithNotifiers adds notifiers that Run hands new alerts to.
+func WithNotifiers(notifiers ...Notifier) WatcherOption {
+	return func(w *Watcher) {
+		w.notifiers = append(w.notifiers, notifiers...)
+	}
+}
+
+// WithNotifyAttempts sets how many polls Run tries to deliver an alert to a
+// failing notifier before it gives up on that notifier and reports the
+// dropped alerts to the error handler. The default is 12, an hour at the
+// default interval.
+func WithNotifyAttempts(n int) WatcherOption {
+	return func(w *Watcher) {
+		if n > 0 {
+			w.notifyAttempts = n
+		}
+	}
+}
+
+// WithWatchErrorHandler sets a function that Run calls with poll errors,
+// notifier errors and state file errors. By default they are dropped.
+func WithWatchErrorHandler(fn func(error)) WatcherOption {
+	return func(w *Watcher) {
+		w.onError = fn
+	}
+}
+
+// WithWatchState restores the seen set and undelivered alerts from an
+// earlier run.
+func WithWatchState(state WatchState) WatcherOption {
+	return func(w *Watcher) {
+		for key, t := range state.Seen {
+			w.seen[key] = t
+		}
+		for code, t := range state.LastAlerted {
+			w.lastAlerted[code] = t
+		}
+		w.pending = append(w.pending, state.Pending...)
+		w.primed = w.primed || len(state.Seen) > 0 || len(state.Pending) > 0
+	}
+}
+
+// WithWatchStateFile makes Run load the state from path when it starts and
+// save it after every poll.
+func WithWatchStateFile(path string) WatcherOption {
+	return func(w *Watcher) {
+		w.stateFile = path
+	}
+}
+
+// WithAlertOnFirstPoll reports everything found by the first poll of a
+// watcher that has no saved state. By default that poll only fills the seen
+// set, so that starting a watcher does not replay the last few days.
+func WithAlertOnFirstPoll() WatcherOption {
+	return func(w *Watcher) {
+		w.alertOnFirst = true
+	}
+}
+
+// WithSuppressedSpecies never reports the given species codes.
+func WithSuppressedSpecies(speciesCodes ...string) WatcherOption {
+	return func(w *Watcher) {
+		for _, code := range speciesCodes {
+			w.suppressed[code] = true
+		}
+	}
+}
+
+// WithSpeciesCooldown reports each species at most once per d. Later reports
+// of the species within d are marked as seen without an alert.
+func WithSpeciesCooldown(d time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.cooldown = d
+	}
+}
+
+// WithQuietHours holds alerts found from startHour to endHour, in loc, and
+// delivers them with the first poll after the quiet hours end. The range
+// wraps past midnight when startHour is greater than endHour, so (22, 7)
+// holds alerts overnight. A nil loc means time.Local.
+func WithQuietHours(startHour, endHour int, loc *time.Location) WatcherOption {
+	return func(w *Watcher) {
+		if loc == nil {
+			loc = time.Local
+		}
+		w.quietStart, w.quietEnd, w.quietLoc = startHour, endHour, loc
+	}
+}
+
+func NewWatcher(client ObservationsAPI, targets []WatchTarget, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		client:         client,
+		targets:        targets,
+		interval:       defaultWatchInterval,
+		retention:      defaultWatchRetention,
+		notifyAttempts: defaultWatchNotifyAttempts,
+		suppressed:     make(map[string]bool),
+		now:            time.Now,
+		seen:           make(map[string]time.Time),
+		lastAlerted:    make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// State returns a snapshot of the seen set and the undelivered alerts.
+func (w *Watcher) State() WatchState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state := WatchState{
+		Seen:        make(map[string]time.Time, len(w.seen)),
+		LastAlerted: make(map[string]time.Time, len(w.lastAlerted)),
+	}
+	for key, t := range w.seen {
+		state.Seen[key] = t
+	}
+	for code, t := range w.lastAlerted {
+		state.LastAlerted[code] = t
+	}
+	state.Pending = make([]PendingAlert, len(w.pending))
+	for i, p := range w.pending {
+		p.Notifiers = append([]int(nil), p.Notifiers...)
+		state.Pending[i] = p
+	}
+	return state
+}
+
+// Run polls the targets every interval until ctx is cancelled, and hands new
+// alerts to the notifiers. An alert that a notifier fails to accept is kept,
+// and saved in the state file, and retried on later polls with that notifier
+// only, up to the WithNotifyAttempts limit. It is marked as seen once every
+// notifier has accepted it or been given up on. Run returns ctx.Err(), or an
+// error if the state file cannot be loaded.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.stateFile != "" {
+		state, err := LoadWatchState(w.stateFile)
+		if err != nil {
+			return err
+		}
+		WithWatchState(state)(w)
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) tick(ctx context.Context) {
+	alerts, err := w.poll(ctx)
+	if err != nil && ctx.Err() == nil {
+		w.reportError(err)
+	}
+
+	w.mu.Lock()
+	for _, a := range alerts {
+		p := PendingAlert{Alert: a, Notifiers: make([]int, len(w.notifiers))}
+		for i := range p.Notifiers {
+			p.Notifiers[i] = i
+		}
+		w.pending = append(w.pending, p)
+	}
+	var batch []PendingAlert
+	if !w.isQuiet(w.now()) {
+		batch = append(batch, w.pending...)
+	}
+	w.mu.Unlock()
+
+	if len(batch) > 0 {
+		w.deliver(ctx, batch)
+	}
+
+	if w.stateFile != "" {
+		if err := w.State().Save(w.stateFile); err != nil {
+			w.reportError(err)
+		}
+	}
+}
+
+// deliver hands each notifier the alerts of batch it has not accepted yet,
+// and removes batch, a prefix of w.pending, from the pending alerts that no
+// notifier still owes.
+func (w *Watcher) deliver(ctx context.Context, batch []PendingAlert) {
+	failed := make(map[int]bool)
+	for i, n := range w.notifiers {
+		var owed []Alert
+		for _, p := range batch {
+			if containsInt(p.Notifiers, i) {
+				owed = append(owed, p.Alert)
+			}
+		}
+		if len(owed) == 0 {
+			continue
+		}
+		if err := n.Notify(ctx, owed); err != nil {
+			w.reportError(fmt.Errorf("failed to notify: %w", err))
+			failed[i] = true
+		}
+	}
+
+	dropped := make(map[int]int)
+	var remaining []PendingAlert
+	var done []Alert
+
+	w.mu.Lock()
+	for _, p := range w.pending[:len(batch)] {
+		p.Attempts++
+		var left []int
+		for _, i := range p.Notifiers {
+			if failed[i] {
+				left = append(left, i)
+			}
+		}
+		if len(left) > 0 && p.Attempts >= w.notifyAttempts {
+			for _, i := range left {
+				dropped[i]++
+			}
+			left = nil
+		}
+		if len(left) == 0 {
+			done = append(done, p.Alert)
+			continue
+		}
+		p.Notifiers = left
+		remaining = append(remaining, p)
+	}
+	w.pending = append(remaining, w.pending[len(batch):]...)
+	w.markSeen(done)
+	w.mu.Unlock()
+
+	for i := 0; i < len(w.notifiers); i++ {
+		if dropped[i] > 0 {
+			w.reportError(fmt.Errorf("notifier %d: dropped %d alerts after %d attempts", i, dropped[i], w.notifyAttempts))
+		}
+	}
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Poll fetches every target once and returns the notable observations not
+// seen before, in observation date order, and marks them as seen. Suppressed
+// species and species in their cooldown are marked as seen but not returned.
+// Targets that fail are reported in a *WatchError.
+func (w *Watcher) Poll(ctx context.Context) ([]Alert, error) {
+	alerts, err := w.poll(ctx)
+	w.mu.Lock()
+	w.markSeen(alerts)
+	w.mu.Unlock()
+	return alerts, err
+}
+
+// poll is Poll without marking the returned alerts as seen. Observations
+// already waiting in w.pending are not returned again.
+func (w *Watcher) poll(ctx context.Context) ([]Alert, error) {
+	var alerts []Alert
+	var failures []TargetError
+
+	w.mu.Lock()
+	now := w.now()
+	priming := !w.primed && !w.alertOnFirst
+	w.prune(now)
+	found := make(map[string]bool, len(w.pending))
+	for _, p := range w.pending {
+		found[alertKey(p.Observation)] = true
+	}
+	w.mu.Unlock()
+
+	for _, target := range w.targets {
+		obs, err := w.fetch(ctx, target)
+		if err != nil {
+			failures = append(failures, TargetError{Target: target, Err: err})
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		w.mu.Lock()
+		for _, o := range obs {
+			key := alertKey(o)
+			if _, ok := w.seen[key]; ok || found[key] {
+				continue
+			}
+			if priming || w.suppress(o.SpeciesCode, now) {
+				w.seen[key] = now
+				continue
+			}
+			found[key] = true
+			w.lastAlerted[o.SpeciesCode] = now
+			alerts = append(alerts, Alert{Observation: o, Target: target, FoundAt: now})
+		}
+		w.mu.Unlock()
+	}
+
+	w.mu.Lock()
+	if len(failures) < len(w.targets) {
+		w.primed = true
+	}
+	w.mu.Unlock()
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].ObsDt < alerts[j].ObsDt
+	})
+
+	if len(failures) > 0 {
+		return alerts, &WatchError{Failures: failures}
+	}
+	return alerts, nil
+}
+
+func alertKey(o Observation) string {
+	return o.SubId + "/" + o.SpeciesCode
+}
+
+// markSeen records alerts as seen. The caller holds w.mu.
+func (w *Watcher) markSeen(alerts []Alert) {
+	for _, a := range alerts {
+		w.seen[alertKey(a.Observation)] = a.FoundAt
+	}
+}
+
+func (w *Watcher) fetch(ctx context.Context, target WatchTarget) ([]Observation, error) {
+	if target.RegionCode != "" {
+		return w.client.RecentNotableObservationsInRegion(ctx, target.RegionCode, w.requestOpts...)
+	}
+
+	opts := []RequestOption{Lat(target.Lat), Lng(target.Lng)}
+	if target.Dist > 0 {
+		opts = append(opts, Dist(target.Dist))
+	}
+	return w.client.RecentNearbyNotableObservations(ctx, append(opts, w.requestOpts...)...)
+}
+
+// suppress reports whether an alert for speciesCode should be dropped. The
+// caller holds w.mu.
+func (w *Watcher) suppress(speciesCode string, now time.Time) bool {
+	if w.suppressed[speciesCode] {
+		return true
+	}
+	if w.cooldown > 0 {
+		if last, ok := w.lastAlerted[speciesCode]; ok && now.Sub(last) < w.cooldown {
+			return true
+		}
+	}
+	return false
+}
+
+// prune forgets observations older than the retention. The caller holds
+// w.mu.
+func (w *Watcher) prune(now time.Time) {
+	for key, t := range w.seen {
+		if now.Sub(t) > w.retention {
+			delete(w.seen, key)
+		}
+	}
+	for code, t := range w.lastAlerted {
+		if now.Sub(t) > w.retention {
+			delete(w.lastAlerted, code)
+		}
+	}
+}
+
+func (w *Watcher) isQuiet(t time.Time) bool {
+	if w.quietLoc == nil || w.quietStart == w.quietEnd {
+		return false
+	}
+	hour := t.In(w.quietLoc).Hour()
+	if w.quietStart < w.quietEnd {
+		return hour >= w.quietStart && hour < w.quietEnd
+	}
+	return hour >= w.quietStart || hour < w.quietEnd
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
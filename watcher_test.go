@@ -0,0 +1,337 @@
+package ebird
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notableServer serves the observations in responses, keyed by request path,
+// and lets tests change them between polls.
+type notableServer struct {
+	mu        sync.Mutex
+	responses map[string][]Observation
+}
+
+func (s *notableServer) set(path string, obs ...Observation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[path] = obs
+}
+
+func (s *notableServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obs, ok := s.responses[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(obs)
+}
+
+func newWatchTest(t *testing.T) (*notableServer, *Client) {
+	t.Helper()
+	ns := &notableServer{responses: map[string][]Observation{}}
+	server := httptest.NewServer(ns)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+	return ns, client
+}
+
+func speciesCodes(alerts []Alert) []string {
+	codes := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		codes = append(codes, a.SpeciesCode)
+	}
+	return codes
+}
+
+func TestWatcherPollDeduplicates(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable",
+		Observation{SpeciesCode: "snoowl1", SubId: "S1", ObsDt: "2024-01-02 09:00"},
+		Observation{SpeciesCode: "gyrfal", SubId: "S2", ObsDt: "2024-01-01 09:00"},
+	)
+	ns.set("/data/obs/geo/recent/notable",
+		Observation{SpeciesCode: "snoowl1", SubId: "S1", ObsDt: "2024-01-02 09:00"},
+	)
+
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}, {Lat: 40.6, Lng: -73.5, Dist: 10}}, WithAlertOnFirstPoll())
+
+	alerts, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gyrfal", "snoowl1"}, speciesCodes(alerts))
+	assert.Equal(t, "US-NY", alerts[0].Target.RegionCode)
+
+	alerts, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	ns.set("/data/obs/US-NY/recent/notable",
+		Observation{SpeciesCode: "snoowl1", SubId: "S1"},
+		Observation{SpeciesCode: "snoowl1", SubId: "S3"},
+	)
+	alerts, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "S3", alerts[0].SubId)
+}
+
+func TestWatcherFirstPollPrimes(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}})
+	alerts, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	ns.set("/data/obs/US-NY/recent/notable",
+		Observation{SpeciesCode: "snoowl1", SubId: "S1"},
+		Observation{SpeciesCode: "gyrfal", SubId: "S2"},
+	)
+	alerts, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gyrfal"}, speciesCodes(alerts))
+
+	restored := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}}, WithWatchState(w.State()))
+	alerts, err = restored.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+}
+
+func TestWatcherSuppression(t *testing.T) {
+	ns, client := newWatchTest(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}},
+		WithAlertOnFirstPoll(),
+		WithSuppressedSpecies("rocpig"),
+		WithSpeciesCooldown(time.Hour),
+	)
+	w.now = func() time.Time { return now }
+
+	ns.set("/data/obs/US-NY/recent/notable",
+		Observation{SpeciesCode: "rocpig", SubId: "S1"},
+		Observation{SpeciesCode: "snoowl1", SubId: "S1"},
+	)
+	alerts, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"snoowl1"}, speciesCodes(alerts))
+
+	now = now.Add(30 * time.Minute)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S2"})
+	alerts, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	now = now.Add(time.Hour)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S3"})
+	alerts, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"snoowl1"}, speciesCodes(alerts))
+}
+
+func TestWatcherPollReportsFailedTargets(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}, {RegionCode: "US-NJ"}}, WithAlertOnFirstPoll())
+	alerts, err := w.Poll(context.Background())
+	assert.Len(t, alerts, 1)
+
+	var watchErr *WatchError
+	require.ErrorAs(t, err, &watchErr)
+	require.Len(t, watchErr.Failures, 1)
+	assert.Equal(t, "US-NJ", watchErr.Failures[0].Target.RegionCode)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestWatcherQuietHours(t *testing.T) {
+	ns, client := newWatchTest(t)
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	var delivered [][]Alert
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}},
+		WithAlertOnFirstPoll(),
+		WithQuietHours(22, 7, time.UTC),
+		WithNotifiers(NotifierFunc(func(ctx context.Context, alerts []Alert) error {
+			delivered = append(delivered, alerts)
+			return nil
+		})),
+	)
+	w.now = func() time.Time { return now }
+
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+	w.tick(context.Background())
+	assert.Empty(t, delivered)
+
+	now = now.Add(8 * time.Hour)
+	ns.set("/data/obs/US-NY/recent/notable",
+		Observation{SpeciesCode: "snoowl1", SubId: "S1"},
+		Observation{SpeciesCode: "gyrfal", SubId: "S2"},
+	)
+	w.tick(context.Background())
+	require.Len(t, delivered, 1)
+	assert.ElementsMatch(t, []string{"snoowl1", "gyrfal"}, speciesCodes(delivered[0]))
+}
+
+func TestWatcherRetriesFailedNotifications(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+
+	fail := true
+	var delivered [][]Alert
+	var errs []error
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}},
+		WithAlertOnFirstPoll(),
+		WithNotifiers(NotifierFunc(func(ctx context.Context, alerts []Alert) error {
+			if fail {
+				return errors.New("webhook returned 500")
+			}
+			delivered = append(delivered, alerts)
+			return nil
+		})),
+		WithWatchErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+
+	w.tick(context.Background())
+	require.Len(t, errs, 1)
+	assert.NotContains(t, w.State().Seen, "S1/snoowl1")
+	assert.Len(t, w.State().Pending, 1)
+
+	fail = false
+	w.tick(context.Background())
+	require.Len(t, delivered, 1)
+	assert.Equal(t, []string{"snoowl1"}, speciesCodes(delivered[0]))
+	assert.Contains(t, w.State().Seen, "S1/snoowl1")
+	assert.Empty(t, w.State().Pending)
+
+	w.tick(context.Background())
+	assert.Len(t, delivered, 1)
+}
+
+func TestWatcherRetriesOnlyFailedNotifier(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+
+	var healthy, failing int
+	var errs []error
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}},
+		WithAlertOnFirstPoll(),
+		WithNotifyAttempts(3),
+		WithNotifiers(
+			NotifierFunc(func(ctx context.Context, alerts []Alert) error {
+				healthy += len(alerts)
+				return nil
+			}),
+			NotifierFunc(func(ctx context.Context, alerts []Alert) error {
+				failing += len(alerts)
+				return errors.New("webhook returned 500")
+			}),
+		),
+		WithWatchErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+
+	w.tick(context.Background())
+	assert.Equal(t, 1, healthy)
+	assert.Equal(t, 1, failing)
+	require.Len(t, w.State().Pending, 1)
+	assert.Equal(t, []int{1}, w.State().Pending[0].Notifiers)
+	assert.NotContains(t, w.State().Seen, "S1/snoowl1")
+
+	w.tick(context.Background())
+	w.tick(context.Background())
+	assert.Equal(t, 1, healthy)
+	assert.Equal(t, 3, failing)
+	assert.Empty(t, w.State().Pending)
+	assert.Contains(t, w.State().Seen, "S1/snoowl1")
+	require.Len(t, errs, 4)
+	assert.EqualError(t, errs[3], "notifier 1: dropped 1 alerts after 3 attempts")
+
+	w.tick(context.Background())
+	assert.Equal(t, 1, healthy)
+	assert.Equal(t, 3, failing)
+}
+
+func TestWatcherQuietHoursSurviveRestart(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+	path := filepath.Join(t.TempDir(), "seen.json")
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	var delivered [][]Alert
+	newWatcher := func(state WatchState) *Watcher {
+		w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}},
+			WithAlertOnFirstPoll(),
+			WithWatchState(state),
+			WithWatchStateFile(path),
+			WithQuietHours(22, 7, time.UTC),
+			WithNotifiers(NotifierFunc(func(ctx context.Context, alerts []Alert) error {
+				delivered = append(delivered, alerts)
+				return nil
+			})),
+		)
+		w.now = func() time.Time { return now }
+		return w
+	}
+
+	newWatcher(WatchState{}).tick(context.Background())
+	assert.Empty(t, delivered)
+
+	state, err := LoadWatchState(path)
+	require.NoError(t, err)
+	require.Len(t, state.Pending, 1)
+
+	now = now.Add(8 * time.Hour)
+	newWatcher(state).tick(context.Background())
+	require.Len(t, delivered, 1)
+	assert.Equal(t, []string{"snoowl1"}, speciesCodes(delivered[0]))
+}
+
+func TestWatcherRunSavesState(t *testing.T) {
+	ns, client := newWatchTest(t)
+	ns.set("/data/obs/US-NY/recent/notable", Observation{SpeciesCode: "snoowl1", SubId: "S1"})
+	path := filepath.Join(t.TempDir(), "seen.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := NewWatcher(client, []WatchTarget{{RegionCode: "US-NY"}},
+		WithWatchStateFile(path),
+		WithNotifiers(NotifierFunc(func(ctx context.Context, alerts []Alert) error { return nil })),
+		WithWatchErrorHandler(func(err error) { t.Error(err) }),
+	)
+
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+	require.Eventually(t, func() bool {
+		state, err := LoadWatchState(path)
+		return err == nil && len(state.Seen) == 1
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	state, err := LoadWatchState(path)
+	require.NoError(t, err)
+	assert.Contains(t, state.Seen, "S1/snoowl1")
+}
+
+func TestAlertString(t *testing.T) {
+	a := Alert{
+		Observation: Observation{ComName: "Snowy Owl", SpeciesCode: "snoowl1", HowMany: 2, LocName: "Jones Beach", ObsDt: "2024-01-05 10:12", SubId: "S123"},
+		Target:      WatchTarget{RegionCode: "US-NY"},
+	}
+	assert.Equal(t, "Snowy Owl x2 at Jones Beach on 2024-01-05 10:12 (US-NY) https://ebird.org/checklist/S123", a.String())
+	assert.Equal(t, "40.60,-73.50 (10 km)", WatchTarget{Lat: 40.6, Lng: -73.5, Dist: 10}.String())
+}